@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/jacobbrewer1/vector-config-controller/pkg/vector"
+	"github.com/jacobbrewer1/web/logging"
+)
+
+// runtimeEnvVars are set in every container by kubernetes or the container runtime.
+var runtimeEnvVars = []string{"HOME", "HOSTNAME", "PATH"}
+
+// checkAgentEnv warns about the environment variables the vector config needs that the agent DaemonSet does not
+// set. Problems reading the DaemonSet are logged rather than failing the reconciliation, and the check is skipped,
+// reporting nothing missing. Reading the DaemonSet needs "get" on daemonsets in the apps API group.
+func checkAgentEnv(
+	ctx context.Context,
+	l *slog.Logger,
+	kubeClient kubernetes.Interface,
+	daemonSetName string,
+	vCfg *vector.Config,
+) {
+	ds, err := kubeClient.AppsV1().DaemonSets(agentConfigNamespace).Get(ctx, daemonSetName, metav1.GetOptions{})
+	switch {
+	case k8serrors.IsNotFound(err):
+		missingEnvVarsGauge.Set(0)
+		l.Debug("agent daemonset not found, skipping environment check",
+			slog.String("daemonset", daemonSetName),
+		)
+		return
+	case err != nil:
+		missingEnvVarsGauge.Set(0)
+		l.Warn("failed to get agent daemonset",
+			slog.String("daemonset", daemonSetName),
+			slog.String(logging.KeyError, err.Error()),
+		)
+		return
+	}
+
+	missing, ok := missingAgentEnv(vCfg.EnvVars(), &ds.Spec.Template.Spec)
+	if !ok {
+		missingEnvVarsGauge.Set(0)
+		l.Debug("agent daemonset sets environment variables from other resources, skipping environment check",
+			slog.String("daemonset", daemonSetName),
+		)
+		return
+	}
+
+	missingEnvVarsGauge.Set(float64(len(missing)))
+	for _, name := range missing {
+		l.Warn("vector config references an environment variable the agent does not set",
+			slog.String("daemonset", daemonSetName),
+			slog.String("env_var", name),
+		)
+	}
+}
+
+// missingAgentEnv returns the names of the referenced environment variables without a default that no container of
+// the pod sets. It returns false if the pod sets variables through envFrom, as those cannot be known.
+func missingAgentEnv(refs []vector.EnvVar, pod *corev1.PodSpec) ([]string, bool) {
+	set := slices.Clone(runtimeEnvVars)
+	for i := range pod.Containers {
+		if len(pod.Containers[i].EnvFrom) > 0 {
+			return nil, false
+		}
+		for _, env := range pod.Containers[i].Env {
+			set = append(set, env.Name)
+		}
+	}
+
+	missing := make([]string, 0)
+	for _, ref := range refs {
+		if ref.HasDefault() || slices.Contains(set, ref.Name) || slices.Contains(missing, ref.Name) {
+			continue
+		}
+		missing = append(missing, ref.Name)
+	}
+	return missing, true
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/jacobbrewer1/vector-config-controller/pkg/vector"
+)
+
+func TestMissingAgentEnv(t *testing.T) {
+	vCfg := vector.NewConfig()
+	vCfg.AddSource("logs", &vector.KubernetesLogsSource{SelfNodeName: "${VECTOR_SELF_NODE_NAME}"})
+	vCfg.AddSink("loki", &vector.LokiSink{
+		Inputs:   []string{"logs"},
+		Endpoint: "${LOKI_ENDPOINT:-http://loki:3100}",
+		Labels: map[string]string{
+			"vector_instance": "inf-${HOSTNAME}",
+			"tenant":          "${TENANT_ID:?tenant is required}",
+		},
+	})
+
+	pod := &corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name: "vector",
+			Env: []corev1.EnvVar{
+				{Name: "VECTOR_SELF_NODE_NAME"},
+			},
+		}},
+	}
+
+	missing, ok := missingAgentEnv(vCfg.EnvVars(), pod)
+	require.True(t, ok)
+	require.Equal(t, []string{"TENANT_ID"}, missing)
+
+	pod.Containers[0].EnvFrom = []corev1.EnvFromSource{{
+		ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "vector-env"}},
+	}}
+	_, ok = missingAgentEnv(vCfg.EnvVars(), pod)
+	require.False(t, ok)
+}
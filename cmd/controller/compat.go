@@ -0,0 +1,42 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/jacobbrewer1/vector-config-controller/pkg/vector"
+)
+
+// recordCompatibility exposes the compatibility issues of the vector config as metrics.
+func recordCompatibility(vCfg *vector.Config, rewritten []vector.CompatibilityIssue) {
+	counts := map[vector.CompatibilityStatus]int{
+		vector.CompatibilityDeprecated:  0,
+		vector.CompatibilityRemoved:     0,
+		vector.CompatibilityUnsupported: 0,
+	}
+	for _, issue := range vCfg.CheckCompatibility() {
+		counts[issue.Status]++
+	}
+
+	for status, count := range counts {
+		compatibilityIssuesGauge.WithLabelValues(string(status)).Set(float64(count))
+	}
+	compatibilityRewritesGauge.Set(float64(len(rewritten)))
+}
+
+// logCompatibility logs the deprecated options that were rewritten and the compatibility issues that remain.
+func logCompatibility(l *slog.Logger, vCfg *vector.Config, rewritten []vector.CompatibilityIssue) {
+	for _, issue := range rewritten {
+		l.Info("rewrote deprecated vector config",
+			slog.String("target_version", vCfg.TargetVersion()),
+			slog.String("issue", issue.String()),
+		)
+	}
+
+	for _, issue := range vCfg.CheckCompatibility() {
+		l.Warn("vector config does not suit the agent's vector version",
+			slog.String("target_version", vCfg.TargetVersion()),
+			slog.String("status", string(issue.Status)),
+			slog.String("issue", issue.String()),
+		)
+	}
+}
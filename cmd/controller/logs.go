@@ -2,33 +2,37 @@ package main
 
 import "github.com/jacobbrewer1/vector-config-controller/pkg/vector"
 
-func configForLogs(vCfg *vector.Config) {
-	vCfg.AddSourceUntyped("kubernetes_logs", map[string]any{
-		"type": "kubernetes_logs",
-	})
+func configForLogs(vCfg *vector.Config) error {
+	return vector.Pipeline("logs").
+		From("kubernetes_logs", new(vector.KubernetesLogsSource)).
+		To("loki_logs", lokiSink()).
+		WithMetadata(vector.Metadata{
+			Origin:      "configForLogs",
+			Description: "Ships the logs of every pod on the node to loki",
+		}).
+		Build(vCfg)
+}
 
-	vCfg.AddSinkUntyped("loki_logs", map[string]any{
-		"type": "loki",
-		"inputs": []string{
-			"kubernetes_logs",
-		},
-		"endpoint":            "http://loki-distributor.loki.svc.cluster.local:3100",
-		"out_of_order_action": "accept",
-		"acknowledgements": map[string]any{
-			"enabled": true,
+// lokiSink returns the sink shipping logs to loki.
+func lokiSink() *vector.LokiSink {
+	return &vector.LokiSink{
+		Endpoint:         "http://loki-distributor.loki.svc.cluster.local:3100",
+		OutOfOrderAction: "accept",
+		Acknowledgements: &vector.Acknowledgements{
+			Enabled: true,
 		},
-		"encoding": map[string]any{
-			"codec": "json",
+		Encoding: vector.Encoding{
+			Codec: "json",
 		},
-		"request": map[string]any{
-			"concurrency": "adaptive",
+		Request: &vector.Request{
+			Concurrency: vector.ConcurrencyAdaptive,
 		},
-		"labels": map[string]any{
+		Labels: map[string]string{
 			"pod_labels_*":    "{{ kubernetes.pod_labels }}",
 			"*":               "{{ metadata }}",
 			"source":          "vector",
 			"vector_instance": "inf-${HOSTNAME}",
 			"tenant_id":       "vector",
 		},
-	})
+	}
 }
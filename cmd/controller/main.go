@@ -3,10 +3,14 @@ package main
 import (
 	"fmt"
 	"log/slog"
+	"net/http"
+	"slices"
 	"time"
 
 	"github.com/caarlos0/env/v10"
 
+	"github.com/jacobbrewer1/vector-config-controller/pkg/vector"
+
 	"github.com/jacobbrewer1/web"
 	"github.com/jacobbrewer1/web/logging"
 )
@@ -20,6 +24,38 @@ type (
 	AppConfig struct {
 		// TickerInterval is the interval for the ticker.
 		TickerInterval time.Duration `env:"TICKER_INTERVAL" envDefault:"10s"`
+
+		// ConfigFormat is the format the vector config is written in.
+		ConfigFormat vector.Format `env:"CONFIG_FORMAT" envDefault:"json"`
+
+		// ConfigDir is the directory the agent loads its config from with --config-dir. If set, the vector config is
+		// written as one ConfigMap key per component, e.g. "sources.kubernetes_logs.json", each holding a root level
+		// config file wrapped in the table of its kind. The ConfigMap is mounted into the directory as it is.
+		ConfigDir string `env:"CONFIG_DIR"`
+
+		// VectorVersion is the version of vector the agent runs, which the config is checked and rewritten for.
+		VectorVersion string `env:"VECTOR_VERSION" envDefault:"0.46"`
+
+		// AgentDaemonSet is the name of the vector agent DaemonSet, whose environment is checked against the
+		// environment variables referenced by the vector config. The controller needs "get" on daemonsets in the
+		// apps API group of the vector namespace; without it the check is skipped.
+		AgentDaemonSet string `env:"AGENT_DAEMONSET" envDefault:"vector-agent"`
+
+		// TopologyFormat is the format, "dot" or "mermaid", the topology of the vector config is published in, next to
+		// the provenance report in vector-agent-reports. The topology is not published if it is empty.
+		TopologyFormat string `env:"TOPOLOGY_FORMAT"`
+
+		// TopologyAddr is the address the topology of the vector config is served on at /topology. It is not served
+		// if it is empty.
+		TopologyAddr string `env:"TOPOLOGY_ADDR"`
+
+		// MaxConfigBytes is the size above which the vector config no longer fits in a single ConfigMap. Kubernetes
+		// caps ConfigMaps at 1 MiB, the default leaves room for metadata.
+		MaxConfigBytes int `env:"MAX_CONFIG_BYTES" envDefault:"921600"`
+
+		// OversizeStrategy decides what happens to a vector config larger than MaxConfigBytes: "fail", "gzip" into
+		// binaryData or "shard" across several ConfigMaps, which requires ConfigDir.
+		OversizeStrategy string `env:"OVERSIZE_STRATEGY" envDefault:"fail"`
 	}
 
 	// App is the main application struct.
@@ -43,6 +79,17 @@ func NewApp(l *slog.Logger) (*App, error) {
 		return nil, fmt.Errorf("failed to parse env vars: %w", err)
 	}
 
+	if _, ok := topologyKeys[cfg.TopologyFormat]; cfg.TopologyFormat != "" && !ok {
+		return nil, fmt.Errorf("unknown topology format '%s'", cfg.TopologyFormat)
+	}
+
+	if !slices.Contains(oversizeStrategies, cfg.OversizeStrategy) {
+		return nil, fmt.Errorf("unknown oversize strategy '%s'", cfg.OversizeStrategy)
+	}
+	if cfg.OversizeStrategy == oversizeShard && cfg.ConfigDir == "" {
+		return nil, fmt.Errorf("oversize strategy '%s' requires CONFIG_DIR", oversizeShard)
+	}
+
 	return &App{
 		base:   base,
 		config: cfg,
@@ -57,6 +104,18 @@ func (a *App) Start() error {
 	); err != nil {
 		return err
 	}
+
+	if a.config.TopologyAddr != "" {
+		router := http.NewServeMux()
+		router.Handle("/topology", topologyHandler(a.base.Logger(), a.config.VectorVersion))
+		if err := a.base.StartServer("topology", &http.Server{
+			Addr:              a.config.TopologyAddr,
+			Handler:           router,
+			ReadHeaderTimeout: 10 * time.Second,
+		}); err != nil {
+			return fmt.Errorf("failed to start topology server: %w", err)
+		}
+	}
 	return nil
 }
 
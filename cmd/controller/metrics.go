@@ -12,38 +12,64 @@ var iterationsHistogram = promauto.NewHistogram(prometheus.HistogramOpts{
 	Help: "The seconds taken to process iterations of this reconciler.",
 })
 
-func configForMetrics(vCfg *vector.Config) {
-	vCfg.AddSourceUntyped("host_metrics", map[string]any{
-		"type": "host_metrics",
-		"filesystem": map[string]any{
-			"devices": map[string]any{
-				"exclude": []string{
+var missingEnvVarsGauge = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "vector_config_controller_missing_env_vars",
+	Help: "The number of environment variables referenced by the vector config that the agent does not set.",
+})
+
+var compatibilityIssuesGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "vector_config_controller_compatibility_issues",
+	Help: "The number of component types and options of the vector config that do not suit the agent's vector version.",
+}, []string{"status"})
+
+var compatibilityRewritesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "vector_config_controller_compatibility_rewrites",
+	Help: "The number of deprecated options of the vector config rewritten for the agent's vector version.",
+})
+
+var configSizeGauge = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "vector_config_controller_config_size_bytes",
+	Help: "The size of the rendered vector config, before any compression.",
+})
+
+var configMapSizeGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "vector_config_controller_configmap_size_bytes",
+	Help: "The size of the data held by each ConfigMap the vector config is written to.",
+}, []string{"configmap"})
+
+func configForMetrics(vCfg *vector.Config) error {
+	return vector.Pipeline("metrics").
+		From("host_metrics", hostMetricsSource()).
+		From("internal_metrics", new(vector.InternalMetricsSource)).
+		To("prometheus_exporter", &vector.PrometheusExporterSink{
+			Address: "0.0.0.0:9090",
+		}).
+		WithMetadata(vector.Metadata{
+			Origin:      "configForMetrics",
+			Description: "Exposes node and vector metrics to prometheus",
+		}).
+		Build(vCfg)
+}
+
+// hostMetricsSource returns the source collecting node metrics, skipping the binfmt_misc filesystem.
+func hostMetricsSource() *vector.HostMetricsSource {
+	return &vector.HostMetricsSource{
+		Filesystem: &vector.HostMetricsFilesystem{
+			Devices: &vector.IncludeExclude{
+				Exclude: []string{
 					"binfmt_misc",
 				},
 			},
-			"filesystems": map[string]any{
-				"exclude": []string{
+			Filesystems: &vector.IncludeExclude{
+				Exclude: []string{
 					"binfmt_misc",
 				},
 			},
-			"mountpoints": map[string]any{
-				"exclude": []string{
+			Mountpoints: &vector.IncludeExclude{
+				Exclude: []string{
 					"*/proc/sys/fs/binfmt_misc",
 				},
 			},
 		},
-	})
-
-	vCfg.AddSourceUntyped("internal_metrics", map[string]any{
-		"type": "internal_metrics",
-	})
-
-	vCfg.AddSinkUntyped("prometheus_exporter", map[string]any{
-		"type": "prometheus_exporter",
-		"inputs": []string{
-			"host_metrics",
-			"internal_metrics",
-		},
-		"address": "0.0.0.0:9090",
-	})
+	}
 }
@@ -1,13 +1,18 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 
@@ -16,6 +21,17 @@ import (
 	"github.com/jacobbrewer1/web/logging"
 )
 
+const (
+	// agentConfigName is the name of the ConfigMap holding the vector agent config.
+	agentConfigName = "vector-agent-config"
+
+	// agentConfigNamespace is the namespace of the ConfigMap holding the vector agent config.
+	agentConfigNamespace = "vector"
+
+	// annotationConfigHash is the annotation holding the hash of the config in the ConfigMap.
+	annotationConfigHash = "vector-config-controller/config-hash"
+)
+
 // Reconcile is the main reconciliation loop for the application.
 func (a *App) Reconcile(ctx context.Context) {
 	l := logging.LoggerWithComponent(a.base.Logger(), "reconcile")
@@ -35,7 +51,9 @@ func (a *App) Reconcile(ctx context.Context) {
 
 			if err := reconcile(
 				ctx,
+				l,
 				a.base.KubeClient(),
+				a.config,
 			); err != nil {
 				l.Error("error reconciling", slog.String(logging.KeyError, err.Error()))
 				continue
@@ -50,7 +68,9 @@ func (a *App) Reconcile(ctx context.Context) {
 // reconcile represents one iteration of the reconciliation process.
 func reconcile(
 	ctx context.Context,
+	l *slog.Logger,
 	kubeClient kubernetes.Interface,
+	cfg *AppConfig,
 ) error {
 	t := prometheus.NewTimer(iterationsHistogram)
 	defer t.ObserveDuration()
@@ -58,38 +78,215 @@ func reconcile(
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	agentConfig, err := vectorAgentConfig()
+	vCfg, rewritten, err := buildAgentConfig(cfg.VectorVersion)
 	if err != nil {
 		return err
 	}
+	recordCompatibility(vCfg, rewritten)
+	logCompatibility(l, vCfg, rewritten)
+	logUnusedComponents(l, vCfg)
+	checkAgentEnv(ctx, l, kubeClient, cfg.AgentDaemonSet, vCfg)
 
-	if err := k8s.UpsertResource(
-		ctx,
-		kubeClient,
-		&corev1.ConfigMap{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "vector-agent-config",
-				Namespace: "vector",
-				Labels: map[string]string{
-					"owner": appName,
-				},
-			},
-			Data: map[string]string{
-				"config.json": agentConfig,
-			},
-		},
-	); err != nil {
-		return fmt.Errorf("failed to upsert resource: %w", err)
+	hash, err := vCfg.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to hash vector config: %w", err)
 	}
 
+	data, err := agentConfigData(vCfg, cfg.ConfigFormat, cfg.ConfigDir)
+	if err != nil {
+		return err
+	}
+
+	reports, err := agentReports(vCfg, cfg.TopologyFormat)
+	if err != nil {
+		return err
+	}
+	if err := upsertChanged(ctx, kubeClient, reports); err != nil {
+		return err
+	}
+
+	existing, err := existingAgentConfigMaps(ctx, kubeClient)
+	if err != nil {
+		return err
+	}
+
+	cms, err := agentConfigMaps(data, hash, cfg.OversizeStrategy, cfg.MaxConfigBytes, shardPlacement(existing))
+	if err != nil {
+		return err
+	}
+	recordConfigSize(data, cms)
+
+	if sameConfigMaps(existing, cms) {
+		// Nothing vector cares about has changed.
+		return nil
+	}
+
+	existingData, err := existingAgentData(existing)
+	if err != nil {
+		return err
+	}
+	previous := existingAgentConfig(l, existingData)
+
+	// vector-agent-config is written last, so its shards are in place when the agent picks up the new hash.
+	for _, cm := range slices.Concat(cms[1:], cms[:1]) {
+		if err := k8s.UpsertResource(ctx, kubeClient, cm); err != nil {
+			return fmt.Errorf("failed to upsert resource: %w", err)
+		}
+	}
+
+	if err := removeStaleShards(ctx, kubeClient, existing, cms); err != nil {
+		return err
+	}
+
+	diff, err := vector.Diff(previous, vCfg)
+	if err != nil {
+		return fmt.Errorf("failed to diff vector config: %w", err)
+	}
+
+	l.Info("vector config changed",
+		slog.String("hash", hash),
+		slog.Int("added", diff.Count(vector.ChangeAdded)),
+		slog.Int("removed", diff.Count(vector.ChangeRemoved)),
+		slog.Int("modified", diff.Count(vector.ChangeModified)),
+		slog.String("diff", diff.String()),
+	)
+
 	return nil
 }
 
-func vectorAgentConfig() (string, error) {
-	vCfg := vector.NewConfig()
+// existingAgentConfig parses the vector config currently held in the ConfigMap data, whatever format and layout it
+// was written in. It returns nil if there is no config that can be parsed, in which case everything is reported as
+// added.
+func existingAgentConfig(l *slog.Logger, existing map[string]string) *vector.Config {
+	if len(existing) == 0 {
+		return nil
+	}
+
+	previous, err := vector.ParseFiles(existing)
+	if err != nil {
+		l.Warn("failed to parse existing vector config", slog.String(logging.KeyError, err.Error()))
+		return nil
+	}
+	return previous
+}
+
+// agentConfigData returns the ConfigMap data holding the vector config. The config is written as a single file, or
+// as one root level file per component if configDir is set, so the ConfigMap can be mounted as it is.
+func agentConfigData(vCfg *vector.Config, format vector.Format, configDir string) (map[string]string, error) {
+	if configDir == "" {
+		agentConfig, err := vCfg.Render(format)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{configMapKey(format): agentConfig}, nil
+	}
+
+	files, err := vCfg.FlatFiles(format, configDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render vector config files: %w", err)
+	}
+	return files, nil
+}
+
+// configMapKey returns the ConfigMap data key the vector config is written under.
+func configMapKey(format vector.Format) string {
+	return "config." + format.Extension()
+}
 
-	configForMetrics(vCfg)
-	configForLogs(vCfg)
+// upsertChanged creates or updates the ConfigMap, unless the existing one already holds the same data.
+func upsertChanged(ctx context.Context, kubeClient kubernetes.Interface, cm *corev1.ConfigMap) error {
+	existing, err := kubeClient.CoreV1().ConfigMaps(cm.Namespace).Get(ctx, cm.Name, metav1.GetOptions{})
+	switch {
+	case k8serrors.IsNotFound(err):
+	case err != nil:
+		return fmt.Errorf("failed to get existing config map '%s': %w", cm.Name, err)
+	case sameData(existing, cm):
+		return nil
+	}
 
-	return vCfg.JSON()
+	if err := k8s.UpsertResource(ctx, kubeClient, cm); err != nil {
+		return fmt.Errorf("failed to upsert resource: %w", err)
+	}
+	return nil
+}
+
+// sameData reports whether the existing ConfigMap holds the same data and binary data as cm.
+func sameData(existing, cm *corev1.ConfigMap) bool {
+	return maps.Equal(existing.Data, cm.Data) && maps.EqualFunc(existing.BinaryData, cm.BinaryData, bytes.Equal)
+}
+
+// buildAgentConfig builds the vector agent configuration for the agent's vector version and validates it. Deprecated
+// options with a known replacement are rewritten and returned.
+func buildAgentConfig(version string) (*vector.Config, []vector.CompatibilityIssue, error) {
+	metrics, err := fragment(configForMetrics)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build metrics config: %w", err)
+	}
+
+	logs, err := fragment(configForLogs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build logs config: %w", err)
+	}
+
+	vCfg, err := vector.Merge(
+		vector.Fragment{Name: "metrics", Config: metrics},
+		vector.Fragment{Name: "logs", Config: logs},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to merge vector config: %w", err)
+	}
+
+	vCfg.SetTargetVersion(version)
+	rewritten := vCfg.RewriteDeprecated()
+
+	if errs, _ := validationProblems(vCfg); len(errs) > 0 {
+		return nil, nil, fmt.Errorf("invalid vector config: %w", errors.Join(errs...))
+	}
+
+	return vCfg, rewritten, nil
+}
+
+// validationProblems validates the vector config, splitting the problems found into those that stop it from being
+// written and unused components. Vector only warns about the latter, so one team adding a source before its sink
+// must not freeze the config of every node.
+func validationProblems(vCfg *vector.Config) ([]error, []error) {
+	err := vCfg.Validate()
+	if err == nil {
+		return nil, nil
+	}
+
+	problems := []error{err}
+	var joined interface{ Unwrap() []error }
+	if errors.As(err, &joined) {
+		problems = joined.Unwrap()
+	}
+
+	errs, unused := make([]error, 0), make([]error, 0)
+	for _, problem := range problems {
+		if errors.Is(problem, vector.ErrUnusedComponent) {
+			unused = append(unused, problem)
+			continue
+		}
+		errs = append(errs, problem)
+	}
+	return errs, unused
+}
+
+// logUnusedComponents warns about the sources and transforms of the vector config whose output is not consumed.
+func logUnusedComponents(l *slog.Logger, vCfg *vector.Config) {
+	_, unused := validationProblems(vCfg)
+	for _, problem := range unused {
+		l.Warn("vector config has an unused component",
+			slog.String(logging.KeyError, problem.Error()),
+		)
+	}
+}
+
+// fragment returns the config built by fn.
+func fragment(fn func(*vector.Config) error) (*vector.Config, error) {
+	vCfg := vector.NewConfig()
+	if err := fn(vCfg); err != nil {
+		return nil, err
+	}
+	return vCfg, nil
 }
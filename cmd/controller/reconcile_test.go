@@ -1,11 +1,22 @@
 package main
 
 import (
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/jacobbrewer1/vector-config-controller/pkg/vector"
 )
 
+// testVectorVersion is the vector version the agent config is built for in tests.
+const testVectorVersion = "0.46"
+
 func TestVectorConfig(t *testing.T) {
 	expectedConfig := `{
 		"sources": {
@@ -61,7 +72,152 @@ func TestVectorConfig(t *testing.T) {
 		}
 	}`
 
-	config, err := vectorAgentConfig()
+	vCfg, _, err := buildAgentConfig(testVectorVersion)
+	require.NoError(t, err)
+	config, err := vCfg.Render(vector.FormatJSON)
 	require.NoError(t, err)
 	require.JSONEq(t, expectedConfig, config)
 }
+
+func TestAgentConfigFiles(t *testing.T) {
+	vCfg, _, err := buildAgentConfig(testVectorVersion)
+	require.NoError(t, err)
+
+	data, err := agentConfigData(vCfg, vector.FormatYAML, "/etc/vector")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		"sources.host_metrics.yaml",
+		"sources.internal_metrics.yaml",
+		"sources.kubernetes_logs.yaml",
+		"sinks.loki_logs.yaml",
+		"sinks.prometheus_exporter.yaml",
+	}, slices.Collect(maps.Keys(data)))
+
+	previous := existingAgentConfig(slog.New(slog.DiscardHandler), data)
+	require.NotNil(t, previous)
+	diff, err := vector.Diff(previous, vCfg)
+	require.NoError(t, err)
+	require.True(t, diff.Empty())
+}
+
+func TestAgentConfigProvenance(t *testing.T) {
+	vCfg, _, err := buildAgentConfig(testVectorVersion)
+	require.NoError(t, err)
+
+	reports, err := agentReports(vCfg, topologyMermaid)
+	require.NoError(t, err)
+	require.Equal(t, reportsName, reports.Name)
+	require.ElementsMatch(t, []string{provenanceKey, "topology.mmd"}, slices.Collect(maps.Keys(reports.Data)))
+
+	require.Len(t, vCfg.ComponentsBy("configForLogs"), 2)
+	require.Len(t, vCfg.ComponentsBy("configForMetrics"), 3)
+	for _, p := range vCfg.Provenance() {
+		require.NotEmpty(t, p.Origin, p.Key)
+		require.NotEmpty(t, p.Fragments, p.Key)
+	}
+}
+
+func TestReconcileRevertsDrift(t *testing.T) {
+	ctx := t.Context()
+	l := slog.New(slog.DiscardHandler)
+	kubeClient := fake.NewClientset()
+	cfg := &AppConfig{
+		ConfigFormat:     vector.FormatJSON,
+		VectorVersion:    testVectorVersion,
+		AgentDaemonSet:   "vector-agent",
+		MaxConfigBytes:   921600,
+		OversizeStrategy: oversizeFail,
+	}
+	client := kubeClient.CoreV1().ConfigMaps(agentConfigNamespace)
+
+	require.NoError(t, reconcile(ctx, l, kubeClient, cfg))
+	want, err := client.Get(ctx, agentConfigName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Contains(t, want.Data, configMapKey(vector.FormatJSON))
+	reports, err := client.Get(ctx, reportsName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Contains(t, reports.Data, provenanceKey)
+
+	// A manual edit keeping the hash annotation is reverted.
+	edited := want.DeepCopy()
+	edited.Data[configMapKey(vector.FormatJSON)] = "{}"
+	_, err = client.Update(ctx, edited, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, reconcile(ctx, l, kubeClient, cfg))
+	got, err := client.Get(ctx, agentConfigName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, want.Data, got.Data)
+
+	// Deleted ConfigMaps are restored.
+	require.NoError(t, client.Delete(ctx, agentConfigName, metav1.DeleteOptions{}))
+	require.NoError(t, client.Delete(ctx, reportsName, metav1.DeleteOptions{}))
+	require.NoError(t, reconcile(ctx, l, kubeClient, cfg))
+	got, err = client.Get(ctx, agentConfigName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, want.Data, got.Data)
+	require.Equal(t, want.Annotations, got.Annotations)
+	_, err = client.Get(ctx, reportsName, metav1.GetOptions{})
+	require.NoError(t, err)
+}
+
+func TestReconcileRestoresShards(t *testing.T) {
+	ctx := t.Context()
+	l := slog.New(slog.DiscardHandler)
+	kubeClient := fake.NewClientset()
+	cfg := &AppConfig{
+		ConfigFormat:     vector.FormatJSON,
+		VectorVersion:    testVectorVersion,
+		ConfigDir:        "/etc/vector",
+		AgentDaemonSet:   "vector-agent",
+		MaxConfigBytes:   500,
+		OversizeStrategy: oversizeShard,
+	}
+
+	require.NoError(t, reconcile(ctx, l, kubeClient, cfg))
+	want, err := existingAgentConfigMaps(ctx, kubeClient)
+	require.NoError(t, err)
+	require.Len(t, want, 3)
+
+	// Deleted and edited shards are restored, each key staying in its ConfigMap.
+	client := kubeClient.CoreV1().ConfigMaps(agentConfigNamespace)
+	require.NoError(t, client.Delete(ctx, "vector-agent-config-2", metav1.DeleteOptions{}))
+	edited := want["vector-agent-config-1"].DeepCopy()
+	edited.Data = map[string]string{"vector.json": "{}"}
+	_, err = client.Update(ctx, edited, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, reconcile(ctx, l, kubeClient, cfg))
+	got, err := existingAgentConfigMaps(ctx, kubeClient)
+	require.NoError(t, err)
+	require.Len(t, got, len(want))
+	for name, cm := range want {
+		require.Contains(t, got, name)
+		require.Equal(t, cm.Data, got[name].Data, name)
+	}
+}
+
+func TestBuildAgentConfigTargetsVectorVersion(t *testing.T) {
+	for _, version := range []string{"0.33", "0.45", "v0.46.1"} {
+		vCfg, _, err := buildAgentConfig(version)
+		require.NoError(t, err, version)
+		require.Equal(t, strings.TrimPrefix(version, "v"), vCfg.TargetVersion())
+	}
+}
+
+func TestValidationProblems(t *testing.T) {
+	vCfg := vector.NewConfig()
+	vCfg.AddSource("logs", new(vector.KubernetesLogsSource))
+	vCfg.AddSource("journal", new(vector.JournaldSource))
+	vCfg.AddSink("out", &vector.BlackholeSink{Inputs: []string{"logs"}})
+
+	errs, unused := validationProblems(vCfg)
+	require.Empty(t, errs)
+	require.Len(t, unused, 1)
+	require.ErrorIs(t, unused[0], vector.ErrUnusedComponent)
+
+	vCfg.AddSink("archive", &vector.BlackholeSink{Inputs: []string{"missing"}})
+	errs, unused = validationProblems(vCfg)
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], vector.ErrMissingInput)
+	require.Len(t, unused, 1)
+}
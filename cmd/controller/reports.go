@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jacobbrewer1/vector-config-controller/pkg/vector"
+)
+
+const (
+	// reportsName is the name of the ConfigMap publishing reports about the vector agent config. They are kept out of
+	// vector-agent-config, whose keys vector loads as config.
+	reportsName = "vector-agent-reports"
+
+	// provenanceKey is the ConfigMap data key of the report of where each component of the vector config comes from.
+	provenanceKey = "provenance.json"
+)
+
+// agentReports returns the ConfigMap publishing the provenance of the vector config and, if topologyFormat is set,
+// its topology.
+func agentReports(vCfg *vector.Config, topologyFormat string) (*corev1.ConfigMap, error) {
+	provenance, err := vCfg.ProvenanceReport()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render provenance report: %w", err)
+	}
+
+	data := map[string]string{
+		provenanceKey: provenance,
+	}
+	if topologyFormat != "" {
+		data[topologyKeys[topologyFormat]] = renderTopology(vCfg, topologyFormat)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      reportsName,
+			Namespace: agentConfigNamespace,
+			Labels: map[string]string{
+				"owner": appName,
+			},
+		},
+		Data: data,
+	}, nil
+}
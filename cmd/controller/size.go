@@ -0,0 +1,343 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// oversizeFail fails the reconciliation if the vector config is larger than the size threshold.
+	oversizeFail = "fail"
+
+	// oversizeGzip gzips every key of the vector config into binaryData if it is larger than the size threshold. The
+	// agent has to decompress the keys, e.g. in an init container, before vector can load them.
+	oversizeGzip = "gzip"
+
+	// oversizeShard spreads the files of the vector config across several ConfigMaps if it is larger than the size
+	// threshold. The agent mounts every shard listed by annotationShards into its config directory. It requires the
+	// directory layout.
+	oversizeShard = "shard"
+
+	// gzipSuffix is appended to the keys of the vector config gzipped into binaryData.
+	gzipSuffix = ".gz"
+
+	// labelShardOf is the label naming the ConfigMap a shard of the vector config belongs to.
+	labelShardOf = "vector-config-controller/shard-of"
+
+	// annotationShards is the annotation listing the names of the ConfigMaps the vector config is spread across,
+	// separated by commas. Shard numbers can have gaps, as keys stay in their shard while others empty.
+	annotationShards = "vector-config-controller/shards"
+)
+
+// oversizeStrategies are the supported values of AppConfig.OversizeStrategy.
+var oversizeStrategies = []string{oversizeFail, oversizeGzip, oversizeShard}
+
+// dataSize returns the number of bytes the keys and values of ConfigMap data take up.
+func dataSize[V string | []byte](data map[string]V) int {
+	size := 0
+	for key, value := range data {
+		size += len(key) + len(value)
+	}
+	return size
+}
+
+// configMapSize returns the number of bytes the data and binary data of a ConfigMap take up.
+func configMapSize(cm *corev1.ConfigMap) int {
+	return dataSize(cm.Data) + dataSize(cm.BinaryData)
+}
+
+// agentConfigMaps returns the ConfigMaps holding the vector config data, the first being vector-agent-config. If the
+// data is larger than maxBytes, it is compressed or sharded following the strategy. Sharded keys stay in the
+// ConfigMap named by placement where they fit, see shardPlacement.
+func agentConfigMaps(
+	data map[string]string,
+	hash, strategy string,
+	maxBytes int,
+	placement map[string]string,
+) ([]*corev1.ConfigMap, error) {
+	primary := newAgentConfigMap(agentConfigName, hash)
+	size := dataSize(data)
+	if size <= maxBytes {
+		primary.Data = data
+		return []*corev1.ConfigMap{primary}, nil
+	}
+
+	switch strategy {
+	case oversizeGzip:
+		binaryData, err := gzipData(data)
+		if err != nil {
+			return nil, err
+		}
+		if compressed := dataSize(binaryData); compressed > maxBytes {
+			return nil, fmt.Errorf("vector config is %d bytes compressed, more than the limit of %d bytes",
+				compressed, maxBytes)
+		}
+		primary.BinaryData = binaryData
+		return []*corev1.ConfigMap{primary}, nil
+	case oversizeShard:
+		return shardData(data, hash, maxBytes, placement)
+	default:
+		return nil, fmt.Errorf("vector config is %d bytes, more than the limit of %d bytes", size, maxBytes)
+	}
+}
+
+// shardData spreads the data across ConfigMaps of at most maxBytes each. Keys stay in the ConfigMap placement names
+// as long as it has room, so that a key is not held by two ConfigMaps while they are updated one after the other.
+// Other keys go, in order, to the first ConfigMap with room, or to a new shard.
+func shardData(
+	data map[string]string,
+	hash string,
+	maxBytes int,
+	placement map[string]string,
+) ([]*corev1.ConfigMap, error) {
+	if len(data) < 2 {
+		return nil, fmt.Errorf("vector config is %d bytes in a single file, more than the limit of %d bytes",
+			dataSize(data), maxBytes)
+	}
+
+	keys := slices.Sorted(maps.Keys(data))
+	for _, key := range keys {
+		if entry := len(key) + len(data[key]); entry > maxBytes {
+			return nil, fmt.Errorf("vector config file '%s' is %d bytes, more than the limit of %d bytes",
+				key, entry, maxBytes)
+		}
+	}
+
+	shards := map[int]*corev1.ConfigMap{0: newAgentConfigMap(agentConfigName, hash)}
+	sizes := make(map[int]int)
+	place := func(i int, key string) {
+		shard, ok := shards[i]
+		if !ok {
+			shard = newAgentConfigMap(shardName(i), "")
+			shard.Labels[labelShardOf] = agentConfigName
+			shards[i] = shard
+		}
+		if shard.Data == nil {
+			shard.Data = make(map[string]string)
+		}
+		shard.Data[key] = data[key]
+		sizes[i] += len(key) + len(data[key])
+	}
+
+	pending := make([]string, 0)
+	for _, key := range keys {
+		i, ok := shardIndex(placement[key])
+		if !ok || sizes[i]+len(key)+len(data[key]) > maxBytes {
+			pending = append(pending, key)
+			continue
+		}
+		place(i, key)
+	}
+	for _, key := range pending {
+		i := 0
+		for sizes[i] > 0 && sizes[i]+len(key)+len(data[key]) > maxBytes {
+			i++
+		}
+		place(i, key)
+	}
+
+	result := make([]*corev1.ConfigMap, 0, len(shards))
+	names := make([]string, 0, len(shards))
+	for _, i := range slices.Sorted(maps.Keys(shards)) {
+		result = append(result, shards[i])
+		names = append(names, shards[i].Name)
+	}
+	result[0].Annotations[annotationShards] = strings.Join(names, ",")
+	return result, nil
+}
+
+// shardName returns the name of the ConfigMap holding the i-th shard of the vector config, vector-agent-config
+// being the first.
+func shardName(i int) string {
+	if i == 0 {
+		return agentConfigName
+	}
+	return fmt.Sprintf("%s-%d", agentConfigName, i)
+}
+
+// shardIndex returns the index of the shard of the vector config held by the named ConfigMap.
+func shardIndex(name string) (int, bool) {
+	if name == agentConfigName {
+		return 0, true
+	}
+	suffix, ok := strings.CutPrefix(name, agentConfigName+"-")
+	if !ok {
+		return 0, false
+	}
+	i, err := strconv.Atoi(suffix)
+	if err != nil || i < 1 || shardName(i) != name {
+		return 0, false
+	}
+	return i, true
+}
+
+// shardPlacement returns the name of the existing ConfigMap holding each key of the vector config.
+func shardPlacement(existing map[string]*corev1.ConfigMap) map[string]string {
+	result := make(map[string]string)
+	for name, cm := range existing {
+		for key := range cm.Data {
+			result[key] = name
+		}
+		for key := range cm.BinaryData {
+			result[strings.TrimSuffix(key, gzipSuffix)] = name
+		}
+	}
+	return result
+}
+
+// newAgentConfigMap returns an empty ConfigMap holding vector config. Only vector-agent-config carries the hash.
+func newAgentConfigMap(name, hash string) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: agentConfigNamespace,
+			Labels: map[string]string{
+				"owner": appName,
+			},
+			Annotations: make(map[string]string),
+		},
+	}
+	if hash != "" {
+		cm.Annotations[annotationConfigHash] = hash
+	}
+	return cm
+}
+
+// gzipData compresses every value of the data, appending gzipSuffix to the keys.
+func gzipData(data map[string]string) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(data))
+	for key, value := range data {
+		buf := bytes.NewBuffer(nil)
+		zw := gzip.NewWriter(buf)
+		if _, err := zw.Write([]byte(value)); err != nil {
+			return nil, fmt.Errorf("failed to compress '%s': %w", key, err)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress '%s': %w", key, err)
+		}
+		result[key+gzipSuffix] = buf.Bytes()
+	}
+	return result, nil
+}
+
+// existingAgentConfigMaps returns vector-agent-config and its shards, keyed by name.
+func existingAgentConfigMaps(ctx context.Context, kubeClient kubernetes.Interface) (map[string]*corev1.ConfigMap, error) {
+	client := kubeClient.CoreV1().ConfigMaps(agentConfigNamespace)
+	result := make(map[string]*corev1.ConfigMap)
+
+	primary, err := client.Get(ctx, agentConfigName, metav1.GetOptions{})
+	switch {
+	case k8serrors.IsNotFound(err):
+	case err != nil:
+		return nil, fmt.Errorf("failed to get existing config: %w", err)
+	default:
+		result[primary.Name] = primary
+	}
+
+	shards, err := client.List(ctx, metav1.ListOptions{
+		LabelSelector: labelShardOf + "=" + agentConfigName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list config shards: %w", err)
+	}
+	for i := range shards.Items {
+		result[shards.Items[i].Name] = &shards.Items[i]
+	}
+	return result, nil
+}
+
+// existingAgentData returns the vector config data held by the ConfigMaps, decompressed.
+func existingAgentData(existing map[string]*corev1.ConfigMap) (map[string]string, error) {
+	data := make(map[string]string)
+	for _, name := range slices.Sorted(maps.Keys(existing)) {
+		decompressed, err := gunzipData(existing[name].BinaryData)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(data, existing[name].Data)
+		maps.Copy(data, decompressed)
+	}
+	return data, nil
+}
+
+// gunzipData decompresses the binary data written by gzipData, removing gzipSuffix from the keys. Other keys are
+// left out.
+func gunzipData(binaryData map[string][]byte) (map[string]string, error) {
+	result := make(map[string]string, len(binaryData))
+	for key, value := range binaryData {
+		if !strings.HasSuffix(key, gzipSuffix) {
+			continue
+		}
+
+		zr, err := gzip.NewReader(bytes.NewReader(value))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress '%s': %w", key, err)
+		}
+		decompressed, err := io.ReadAll(zr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress '%s': %w", key, err)
+		}
+		result[strings.TrimSuffix(key, gzipSuffix)] = string(decompressed)
+	}
+	return result, nil
+}
+
+// removeStaleShards deletes the existing shards of vector-agent-config that are no longer part of the vector config.
+func removeStaleShards(
+	ctx context.Context,
+	kubeClient kubernetes.Interface,
+	existing map[string]*corev1.ConfigMap,
+	cms []*corev1.ConfigMap,
+) error {
+	client := kubeClient.CoreV1().ConfigMaps(agentConfigNamespace)
+	for _, name := range slices.Sorted(maps.Keys(existing)) {
+		if name == agentConfigName || slices.ContainsFunc(cms, func(cm *corev1.ConfigMap) bool { return cm.Name == name }) {
+			continue
+		}
+		if err := client.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete config shard '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// recordConfigSize records the size of the rendered vector config and of the ConfigMaps holding it.
+func recordConfigSize(data map[string]string, cms []*corev1.ConfigMap) {
+	configSizeGauge.Set(float64(dataSize(data)))
+	configMapSizeGauge.Reset()
+	for _, cm := range cms {
+		configMapSizeGauge.WithLabelValues(cm.Name).Set(float64(configMapSize(cm)))
+	}
+}
+
+// sameConfigMaps reports whether the existing ConfigMaps are exactly the ones to write: the same names, data and
+// annotations of this controller.
+func sameConfigMaps(existing map[string]*corev1.ConfigMap, cms []*corev1.ConfigMap) bool {
+	if len(existing) != len(cms) {
+		return false
+	}
+	for _, cm := range cms {
+		current, ok := existing[cm.Name]
+		if !ok || !sameData(current, cm) {
+			return false
+		}
+		for _, annotation := range []string{annotationConfigHash, annotationShards} {
+			if current.Annotations[annotation] != cm.Annotations[annotation] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAgentConfigMaps(t *testing.T) {
+	data := map[string]string{
+		"sources.kubernetes_logs.json": strings.Repeat("a", 400),
+		"sinks.loki_logs.json":         strings.Repeat("b", 400),
+		"vector.json":                  strings.Repeat("c", 400),
+	}
+
+	cms, err := agentConfigMaps(data, "hash", oversizeFail, 2048, nil)
+	require.NoError(t, err)
+	require.Len(t, cms, 1)
+	require.Equal(t, data, cms[0].Data)
+	require.Equal(t, "hash", cms[0].Annotations[annotationConfigHash])
+
+	_, err = agentConfigMaps(data, "hash", oversizeFail, 1000, nil)
+	require.EqualError(t, err, "vector config is 1259 bytes, more than the limit of 1000 bytes")
+
+	cms, err = agentConfigMaps(data, "hash", oversizeGzip, 1000, nil)
+	require.NoError(t, err)
+	require.Len(t, cms, 1)
+	require.Empty(t, cms[0].Data)
+	require.LessOrEqual(t, configMapSize(cms[0]), 1000)
+	decompressed, err := gunzipData(cms[0].BinaryData)
+	require.NoError(t, err)
+	require.Equal(t, data, decompressed)
+
+	cms, err = agentConfigMaps(data, "hash", oversizeShard, 1000, nil)
+	require.NoError(t, err)
+	require.Len(t, cms, 2)
+	require.Equal(t, "vector-agent-config", cms[0].Name)
+	require.Equal(t, "vector-agent-config,vector-agent-config-1", cms[0].Annotations[annotationShards])
+	require.Equal(t, "hash", cms[0].Annotations[annotationConfigHash])
+	require.Equal(t, "vector-agent-config-1", cms[1].Name)
+	require.Equal(t, agentConfigName, cms[1].Labels[labelShardOf])
+	require.Empty(t, cms[1].Annotations[annotationConfigHash])
+	require.ElementsMatch(t, []string{"sinks.loki_logs.json", "sources.kubernetes_logs.json"}, slices.Collect(maps.Keys(cms[0].Data)))
+	require.ElementsMatch(t, []string{"vector.json"}, slices.Collect(maps.Keys(cms[1].Data)))
+
+	_, err = agentConfigMaps(data, "hash", oversizeShard, 300, nil)
+	require.EqualError(t, err, "vector config file 'sinks.loki_logs.json' is 420 bytes, more than the limit of 300 bytes")
+}
+
+func TestShardDataKeepsPlacement(t *testing.T) {
+	data := map[string]string{
+		"sources.kubernetes_logs.json": strings.Repeat("a", 400),
+		"sinks.loki_logs.json":         strings.Repeat("b", 400),
+		"vector.json":                  strings.Repeat("c", 400),
+	}
+
+	// Keys stay where they are, new keys fill the first ConfigMap with room.
+	cms, err := shardData(data, "hash", 1000, map[string]string{
+		"vector.json":          agentConfigName,
+		"sinks.loki_logs.json": "vector-agent-config-1",
+	})
+	require.NoError(t, err)
+	require.Len(t, cms, 2)
+	require.ElementsMatch(t, []string{"sources.kubernetes_logs.json", "vector.json"}, slices.Collect(maps.Keys(cms[0].Data)))
+	require.ElementsMatch(t, []string{"sinks.loki_logs.json"}, slices.Collect(maps.Keys(cms[1].Data)))
+	require.Equal(t, shardPlacement(map[string]*corev1.ConfigMap{cms[0].Name: cms[0], cms[1].Name: cms[1]}),
+		map[string]string{
+			"sources.kubernetes_logs.json": agentConfigName,
+			"vector.json":                  agentConfigName,
+			"sinks.loki_logs.json":         "vector-agent-config-1",
+		})
+
+	// A key only moves when its ConfigMap runs out of room.
+	data["vector.json"] = strings.Repeat("c", 600)
+	cms, err = shardData(data, "hash", 1000, shardPlacement(map[string]*corev1.ConfigMap{
+		cms[0].Name: cms[0],
+		cms[1].Name: cms[1],
+	}))
+	require.NoError(t, err)
+	require.Len(t, cms, 3)
+	require.ElementsMatch(t, []string{"sources.kubernetes_logs.json"}, slices.Collect(maps.Keys(cms[0].Data)))
+	require.ElementsMatch(t, []string{"sinks.loki_logs.json"}, slices.Collect(maps.Keys(cms[1].Data)))
+	require.Equal(t, "vector-agent-config-2", cms[2].Name)
+	require.ElementsMatch(t, []string{"vector.json"}, slices.Collect(maps.Keys(cms[2].Data)))
+	require.Equal(t, "vector-agent-config,vector-agent-config-1,vector-agent-config-2",
+		cms[0].Annotations[annotationShards])
+
+	// A shard that empties leaves a gap, which the annotation shows.
+	cms, err = shardData(map[string]string{
+		"a.json": strings.Repeat("a", 400),
+		"c.json": strings.Repeat("c", 400),
+	}, "hash", 500, map[string]string{
+		"a.json": agentConfigName,
+		"c.json": "vector-agent-config-2",
+	})
+	require.NoError(t, err)
+	require.Len(t, cms, 2)
+	require.Equal(t, "vector-agent-config-2", cms[1].Name)
+	require.Equal(t, "vector-agent-config,vector-agent-config-2", cms[0].Annotations[annotationShards])
+
+	_, ok := shardIndex("vector-agent-config-01")
+	require.False(t, ok)
+}
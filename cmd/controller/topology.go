@@ -0,0 +1,58 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/jacobbrewer1/vector-config-controller/pkg/vector"
+	"github.com/jacobbrewer1/web/logging"
+)
+
+const (
+	// topologyDOT draws the topology as a Graphviz digraph.
+	topologyDOT = "dot"
+
+	// topologyMermaid draws the topology as a Mermaid flowchart.
+	topologyMermaid = "mermaid"
+)
+
+// topologyKeys maps each topology format to the key of the reports ConfigMap it is published under.
+var topologyKeys = map[string]string{
+	topologyDOT:     "topology.dot",
+	topologyMermaid: "topology.mmd",
+}
+
+// renderTopology draws the topology of the vector config in the given format.
+func renderTopology(vCfg *vector.Config, format string) string {
+	if format == topologyMermaid {
+		return vCfg.Mermaid()
+	}
+	return vCfg.DOT()
+}
+
+// topologyHandler serves the topology of the vector agent config, as DOT unless the format query parameter asks for
+// mermaid.
+func topologyHandler(l *slog.Logger, version string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = topologyDOT
+		}
+		if _, ok := topologyKeys[format]; !ok {
+			http.Error(w, "unknown topology format", http.StatusBadRequest)
+			return
+		}
+
+		vCfg, _, err := buildAgentConfig(version)
+		if err != nil {
+			l.Error("failed to build vector config for topology", slog.String(logging.KeyError, err.Error()))
+			http.Error(w, "failed to build vector config", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if _, err := w.Write([]byte(renderTopology(vCfg, format))); err != nil {
+			l.Error("failed to write topology", slog.String(logging.KeyError, err.Error()))
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopologyHandler(t *testing.T) {
+	handler := topologyHandler(slog.New(slog.DiscardHandler), testVectorVersion)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/topology?format=mermaid", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `-->`)
+	require.Contains(t, rec.Body.String(), `["loki_logs<br/>loki"]:::sink`)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/topology", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"kubernetes_logs" -> "loki_logs";`)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/topology?format=png", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
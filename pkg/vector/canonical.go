@@ -0,0 +1,150 @@
+package vector
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// unorderedLists are the list options vector treats as sets, so their order does not change the meaning of the
+// configuration.
+var unorderedLists = map[string]bool{
+	"collectors":                  true,
+	"exclude":                     true,
+	"exclude_paths_glob_patterns": true,
+	"exclude_units":               true,
+	"include":                     true,
+	"include_paths_glob_patterns": true,
+	"include_units":               true,
+	"inputs":                      true,
+}
+
+// Canonical returns the normalized JSON form of the configuration. Two configurations that vector treats the same
+// have the same canonical form: keys are sorted, numbers are written in their shortest form, and lists whose
+// order vector ignores, such as inputs, are sorted.
+func (c *Config) Canonical() (string, error) {
+	document, err := c.document()
+	if err != nil {
+		return "", err
+	}
+
+	result := bytes.NewBuffer(nil)
+	if err := writeCanonical(result, "", document); err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+// document returns the rendered configuration as generic JSON values, with numbers decoded as int64 or float64.
+func (c *Config) document() (map[string]any, error) {
+	raw, err := c.JSON()
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := decodeJSON([]byte(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	normalizeNumbers(document)
+	return document, nil
+}
+
+// Hash returns the hex encoded SHA-256 of the canonical form of the configuration.
+func (c *Config) Hash() (string, error) {
+	canonical, err := c.Canonical()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(canonical))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// writeCanonical writes the canonical JSON form of v, which is the value of the option key.
+//
+// The encoding is done by hand rather than through encoding/json so the output does not depend on the behavior of
+// the standard library.
+func writeCanonical(buf *bytes.Buffer, key string, v any) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case int64:
+		buf.WriteString(strconv.FormatInt(v, 10))
+	case float64:
+		writeCanonicalFloat(buf, v)
+	case string:
+		writeCanonicalString(buf, v)
+	case map[string]any:
+		buf.WriteByte('{')
+		for i, k := range sortedKeys(v) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, k)
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, k, v[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			itemBuf := bytes.NewBuffer(nil)
+			if err := writeCanonical(itemBuf, "", item); err != nil {
+				return err
+			}
+			items = append(items, itemBuf.String())
+		}
+		if unorderedLists[key] {
+			slices.Sort(items)
+		}
+
+		buf.WriteByte('[')
+		for i, item := range items {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(item)
+		}
+		buf.WriteByte(']')
+	default:
+		return fmt.Errorf("unexpected value of type %T in config", v)
+	}
+	return nil
+}
+
+// writeCanonicalFloat writes whole numbers as integers, so 15 and 15.0 share a canonical form.
+func writeCanonicalFloat(buf *bytes.Buffer, f float64) {
+	if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		buf.WriteString(strconv.FormatInt(int64(f), 10))
+		return
+	}
+	buf.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// writeCanonicalString writes s as a JSON string, escaping only what JSON requires.
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"':
+			buf.WriteString(`\"`)
+		case r == '\\':
+			buf.WriteString(`\\`)
+		case r < 0x20:
+			fmt.Fprintf(buf, `\u%04x`, r)
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+}
@@ -0,0 +1,62 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonical(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddSource("b", new(InternalMetricsSource))
+	c.AddSource("a", &HostMetricsSource{ScrapeIntervalSecs: 15})
+	c.AddSink("out", &LokiSink{
+		Inputs:   []string{"b", "a"},
+		Endpoint: "http://loki:3100",
+		Encoding: Encoding{Codec: "json"},
+		Labels:   map[string]string{"instance": `inf-"${HOSTNAME}"`},
+	})
+
+	got, err := c.Canonical()
+	require.NoError(t, err)
+	require.Equal(t, `{"sinks":{"out":{"encoding":{"codec":"json"},"endpoint":"http://loki:3100",`+
+		`"inputs":["a","b"],"labels":{"instance":"inf-\"${HOSTNAME}\""},"type":"loki"}},`+
+		`"sources":{"a":{"scrape_interval_secs":15,"type":"host_metrics"},"b":{"type":"internal_metrics"}}}`, got)
+}
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+
+	a, err := ParseJSON([]byte(`{
+		"sources": {"host": {"type": "host_metrics", "scrape_interval_secs": 15.0, "collectors": ["cpu", "disk"]}},
+		"sinks": {"out": {"type": "blackhole", "inputs": ["host", "internal"]}}
+	}`))
+	require.NoError(t, err)
+
+	b, err := ParseYAML([]byte(`
+sinks:
+  out:
+    inputs: [internal, host]
+    type: blackhole
+sources:
+  host:
+    collectors: [disk, cpu]
+    scrape_interval_secs: 15
+    type: host_metrics
+`))
+	require.NoError(t, err)
+
+	hashA, err := a.Hash()
+	require.NoError(t, err)
+	hashB, err := b.Hash()
+	require.NoError(t, err)
+	require.Equal(t, hashA, hashB)
+	require.Len(t, hashA, 64)
+
+	require.NoError(t, b.ReplaceSinkUntyped("out", map[string]any{"type": "blackhole", "inputs": []string{"host"}}))
+	hashB, err = b.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, hashA, hashB)
+}
@@ -0,0 +1,312 @@
+package vector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CompatibilityStatus is the status of a component type or option for the target vector version.
+type CompatibilityStatus string
+
+// Statuses reported by CheckCompatibility.
+const (
+	// CompatibilityDeprecated is reported for types and options that still work but will be removed.
+	CompatibilityDeprecated CompatibilityStatus = "deprecated"
+
+	// CompatibilityRemoved is reported for types and options vector no longer accepts.
+	CompatibilityRemoved CompatibilityStatus = "removed"
+
+	// CompatibilityUnsupported is reported for types and options that are newer than the target version.
+	CompatibilityUnsupported CompatibilityStatus = "unsupported"
+)
+
+// compatibilityRule records when a component type or option was added, deprecated or removed.
+type compatibilityRule struct {
+	kind ComponentKind
+
+	// componentType is the component type, empty for options shared by every type of the kind.
+	componentType string
+
+	// field is the slash separated path of the option, empty for the component type itself.
+	field string
+
+	added      string
+	deprecated string
+	removed    string
+
+	// replacement is the type or option replacing a deprecated one.
+	replacement string
+
+	// convert converts the value of the option into the value of the replacement. A nil convert moves the value
+	// as it is.
+	convert func(v any) any
+}
+
+// compatibilityRules is the compatibility table of vector, covering the components this package models.
+var compatibilityRules = []compatibilityRule{
+	{kind: KindSource, componentType: "generator", deprecated: "0.17", removed: "0.25", replacement: "demo_logs"},
+	{kind: KindSource, componentType: "http", deprecated: "0.23", replacement: "http_server"},
+	{kind: KindTransform, componentType: "swimlanes", deprecated: "0.21", removed: "0.29", replacement: "route"},
+	{kind: KindSink, componentType: "prometheus", deprecated: "0.12", removed: "0.26", replacement: "prometheus_exporter"},
+	{kind: KindSink, componentType: "opentelemetry", added: "0.35"},
+	{kind: KindEnrichmentTable, componentType: "memory", added: "0.44"},
+
+	{kind: KindSource, componentType: "file", field: "start_at_beginning", deprecated: "0.21", replacement: "read_from",
+		convert: func(v any) any {
+			if v == true {
+				return "beginning"
+			}
+			return "end"
+		}},
+	{kind: KindSource, componentType: "journald", field: "units", deprecated: "0.20", replacement: "include_units"},
+	{kind: KindSource, componentType: "kubernetes_logs", field: "use_apiserver_cache", added: "0.34"},
+	{kind: KindSink, componentType: "elasticsearch", field: "bulk_action", deprecated: "0.21", replacement: "bulk/action"},
+	{kind: KindSink, componentType: "elasticsearch", field: "index", deprecated: "0.21", replacement: "bulk/index"},
+	{kind: KindSink, componentType: "elasticsearch", field: "endpoint", deprecated: "0.34", replacement: "endpoints",
+		convert: func(v any) any { return []any{v} }},
+	{kind: KindSink, field: "batch/max_size", deprecated: "0.17", replacement: "batch/max_bytes"},
+	{kind: KindSink, field: "request/in_flight_limit", deprecated: "0.11", replacement: "request/concurrency"},
+}
+
+// CompatibilityIssue is a component type or option of a configuration that does not suit its target version.
+type CompatibilityIssue struct {
+	Kind ComponentKind
+	Key  string
+	Type string
+
+	// Field is the JSON pointer of the option, empty if the issue is the component type itself.
+	Field string
+
+	Status CompatibilityStatus
+
+	// Version is the vector version the type or option was added, deprecated or removed in.
+	Version string
+
+	// Replacement is the type or option to use instead, if any.
+	Replacement string
+}
+
+// String returns a human-readable description of the issue.
+func (i CompatibilityIssue) String() string {
+	subject := fmt.Sprintf("%s type '%s'", i.Kind, i.Type)
+	if i.Field != "" {
+		subject = fmt.Sprintf("option '%s' of %s", i.Field, subject)
+	}
+
+	var result string
+	switch i.Status {
+	case CompatibilityUnsupported:
+		result = fmt.Sprintf("%s '%s': %s requires vector %s", i.Kind, i.Key, subject, i.Version)
+	default:
+		result = fmt.Sprintf("%s '%s': %s is %s since vector %s", i.Kind, i.Key, subject, i.Status, i.Version)
+	}
+
+	if i.Replacement != "" {
+		result += fmt.Sprintf(", use '%s' instead", i.Replacement)
+	}
+	return result
+}
+
+// SetTargetVersion sets the vector version the configuration is written for, e.g. "0.46" or "v0.46.1". It is not
+// part of the rendered configuration.
+func (c *Config) SetTargetVersion(version string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.targetVersion = strings.TrimPrefix(version, "v")
+}
+
+// TargetVersion returns the vector version the configuration is written for, empty if it is not set.
+func (c *Config) TargetVersion() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.targetVersion
+}
+
+// CheckCompatibility returns the component types and options of the configuration that are deprecated, removed or
+// not yet available in the target version. It returns nil if no target version is set.
+func (c *Config) CheckCompatibility() []CompatibilityIssue {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.targetVersion == "" {
+		return nil
+	}
+
+	result := make([]CompatibilityIssue, 0)
+	c.eachRuleMatch(func(key string, cfg map[string]any, rule compatibilityRule) {
+		status, version, ok := rule.status(c.targetVersion)
+		if !ok {
+			return
+		}
+
+		issue := CompatibilityIssue{
+			Kind:        rule.kind,
+			Key:         key,
+			Type:        typeOf(cfg),
+			Status:      status,
+			Version:     version,
+			Replacement: rule.replacement,
+		}
+		if rule.field != "" {
+			issue.Field = "/" + rule.field
+		}
+		if issue.Replacement != "" && rule.field != "" {
+			issue.Replacement = "/" + issue.Replacement
+		}
+		result = append(result, issue)
+	})
+	return result
+}
+
+// RewriteDeprecated replaces the deprecated component types and options of the configuration that have a known
+// replacement, returning the issues it fixed. Options are only moved if their replacement is not set. Nothing is
+// rewritten if no target version is set.
+func (c *Config) RewriteDeprecated() []CompatibilityIssue {
+	fixed := make([]CompatibilityIssue, 0)
+	for _, issue := range c.CheckCompatibility() {
+		if issue.Status == CompatibilityUnsupported || issue.Replacement == "" {
+			continue
+		}
+		if c.rewrite(issue) {
+			fixed = append(fixed, issue)
+		}
+	}
+	return fixed
+}
+
+// rewrite applies the replacement of an issue, reporting whether it did.
+func (c *Config) rewrite(issue CompatibilityIssue) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cfg, ok := c.components(issue.Kind)[issue.Key]
+	if !ok {
+		return false
+	}
+
+	if issue.Field == "" {
+		cfg["type"] = issue.Replacement
+		return true
+	}
+
+	from := strings.TrimPrefix(issue.Field, "/")
+	to := strings.TrimPrefix(issue.Replacement, "/")
+	if _, exists := lookupPath(cfg, to); exists {
+		return false
+	}
+
+	value, _ := lookupPath(cfg, from)
+	for _, rule := range compatibilityRules {
+		if rule.field == from && rule.convert != nil && rule.matches(issue.Kind, issue.Type) {
+			value = rule.convert(value)
+		}
+	}
+
+	deletePath(cfg, from)
+	setPath(cfg, to, value)
+	return true
+}
+
+// eachRuleMatch calls fn for every component matching a compatibility rule. The caller must hold c.mu.
+func (c *Config) eachRuleMatch(fn func(key string, cfg map[string]any, rule compatibilityRule)) {
+	for _, kind := range componentKinds {
+		components := c.components(kind)
+		for _, key := range sortedKeys(components) {
+			cfg := components[key]
+			for _, rule := range compatibilityRules {
+				if !rule.matches(kind, typeOf(cfg)) {
+					continue
+				}
+				if _, ok := lookupPath(cfg, rule.field); rule.field != "" && !ok {
+					continue
+				}
+				fn(key, cfg, rule)
+			}
+		}
+	}
+}
+
+// matches reports whether the rule applies to components of the given kind and type.
+func (r compatibilityRule) matches(kind ComponentKind, componentType string) bool {
+	return r.kind == kind && (r.componentType == "" || r.componentType == componentType)
+}
+
+// status returns the status of the rule's subject in the target version, or false if it is fine to use.
+func (r compatibilityRule) status(target string) (CompatibilityStatus, string, bool) {
+	switch {
+	case r.removed != "" && compareVersions(target, r.removed) >= 0:
+		return CompatibilityRemoved, r.removed, true
+	case r.deprecated != "" && compareVersions(target, r.deprecated) >= 0:
+		return CompatibilityDeprecated, r.deprecated, true
+	case r.added != "" && compareVersions(target, r.added) < 0:
+		return CompatibilityUnsupported, r.added, true
+	default:
+		return "", "", false
+	}
+}
+
+// lookupPath returns the value at the slash separated path of cfg.
+func lookupPath(cfg map[string]any, path string) (any, bool) {
+	var current any = cfg
+	for _, part := range strings.Split(path, "/") {
+		table, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = table[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// setPath sets the value at the slash separated path of cfg, creating tables on the way.
+func setPath(cfg map[string]any, path string, value any) {
+	parts := strings.Split(path, "/")
+	table := cfg
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := table[part].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			table[part] = next
+		}
+		table = next
+	}
+	table[parts[len(parts)-1]] = value
+}
+
+// deletePath removes the value at the slash separated path of cfg.
+func deletePath(cfg map[string]any, path string) {
+	parts := strings.Split(path, "/")
+	table := cfg
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := table[part].(map[string]any)
+		if !ok {
+			return
+		}
+		table = next
+	}
+	delete(table, parts[len(parts)-1])
+}
+
+// compareVersions compares two dotted vector versions numerically.
+func compareVersions(a, b string) int {
+	partsA := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	partsB := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < max(len(partsA), len(partsB)); i++ {
+		var x, y int
+		if i < len(partsA) {
+			x, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			y, _ = strconv.Atoi(partsB[i])
+		}
+		if x != y {
+			return x - y
+		}
+	}
+	return 0
+}
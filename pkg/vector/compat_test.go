@@ -0,0 +1,64 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddSourceUntyped("demo", map[string]any{"type": "generator", "format": "json"})
+	c.AddSourceUntyped("files", map[string]any{"type": "file", "include": []string{"/var/log/*.log"}, "start_at_beginning": true})
+	c.AddSource("logs", &KubernetesLogsSource{UseAPIServerCache: new(bool)})
+	c.AddSinkUntyped("es", map[string]any{
+		"type":     "elasticsearch",
+		"inputs":   []string{"demo", "files", "logs"},
+		"endpoint": "http://es:9200",
+		"index":    "logs-%Y.%m.%d",
+		"batch":    map[string]any{"max_size": 1024},
+	})
+	require.Nil(t, c.CheckCompatibility(), "nothing is reported without a target version")
+
+	c.SetTargetVersion("v0.33.1")
+	require.Equal(t, "0.33.1", c.TargetVersion())
+
+	issues := c.CheckCompatibility()
+	descriptions := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		descriptions = append(descriptions, issue.String())
+	}
+	require.Equal(t, []string{
+		"source 'demo': source type 'generator' is removed since vector 0.25, use 'demo_logs' instead",
+		"source 'files': option '/start_at_beginning' of source type 'file' is deprecated since vector 0.21, use '/read_from' instead",
+		"source 'logs': option '/use_apiserver_cache' of source type 'kubernetes_logs' requires vector 0.34",
+		"sink 'es': option '/index' of sink type 'elasticsearch' is deprecated since vector 0.21, use '/bulk/index' instead",
+		"sink 'es': option '/batch/max_size' of sink type 'elasticsearch' is deprecated since vector 0.17, use '/batch/max_bytes' instead",
+	}, descriptions)
+
+	c.SetTargetVersion("0.46")
+	fixed := c.RewriteDeprecated()
+	require.Len(t, fixed, 5)
+	require.Empty(t, c.CheckCompatibility())
+
+	got, err := c.JSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"sources": {
+			"demo": {"type": "demo_logs", "format": "json"},
+			"files": {"type": "file", "include": ["/var/log/*.log"], "read_from": "beginning"},
+			"logs": {"type": "kubernetes_logs", "use_apiserver_cache": false}
+		},
+		"sinks": {
+			"es": {
+				"type": "elasticsearch",
+				"inputs": ["demo", "files", "logs"],
+				"endpoints": ["http://es:9200"],
+				"bulk": {"index": "logs-%Y.%m.%d"},
+				"batch": {"max_bytes": 1024}
+			}
+		}
+	}`, got)
+}
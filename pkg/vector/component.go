@@ -0,0 +1,121 @@
+package vector
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// IncludeExclude is the include/exclude filter used by several vector components.
+type IncludeExclude struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// componentMap converts a typed component into the untyped representation stored in the configuration, setting
+// the component type on the way through.
+func componentMap(componentType string, component any) (map[string]any, error) {
+	raw, err := json.Marshal(component)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %s component: %w", componentType, err)
+	}
+
+	result := make(map[string]any)
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("error decoding %s component: %w", componentType, err)
+	}
+
+	result["type"] = componentType
+	return result, nil
+}
+
+// ComponentKind is the kind of a vector component.
+type ComponentKind string
+
+// Component kinds of the vector configuration.
+const (
+	KindSource    ComponentKind = "source"
+	KindTransform ComponentKind = "transform"
+	KindSink      ComponentKind = "sink"
+
+	// KindSecretBackend and KindEnrichmentTable are not part of the topology, but share the add, get, remove and
+	// replace semantics of the other kinds.
+	KindSecretBackend   ComponentKind = "secret backend"
+	KindEnrichmentTable ComponentKind = "enrichment table"
+
+	// KindGlobal identifies a top-level global option, such as data_dir, in diffs and reports.
+	KindGlobal ComponentKind = "global option"
+
+	// KindTest identifies a unit test in errors and reports.
+	KindTest ComponentKind = "test"
+)
+
+// documentKinds maps the top-level keys of the configuration holding components to their kind.
+var documentKinds = map[string]ComponentKind{
+	"secret":            KindSecretBackend,
+	"enrichment_tables": KindEnrichmentTable,
+	"sources":           KindSource,
+	"transforms":        KindTransform,
+	"sinks":             KindSink,
+}
+
+// componentKinds are the kinds stored in the configuration, in the order they are processed.
+var componentKinds = []ComponentKind{KindSecretBackend, KindEnrichmentTable, KindSource, KindTransform, KindSink}
+
+// componentInputs returns the inputs of the component configuration.
+func componentInputs(cfg map[string]any) []string {
+	switch inputs := cfg["inputs"].(type) {
+	case []string:
+		return inputs
+	case []any:
+		result := make([]string, 0, len(inputs))
+		for _, input := range inputs {
+			if s, ok := input.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	case string:
+		return []string{inputs}
+	default:
+		return nil
+	}
+}
+
+// typeOf returns the vector type of the component configuration.
+func typeOf(cfg map[string]any) string {
+	t, _ := cfg["type"].(string)
+	return t
+}
+
+// walkStrings calls fn with every string value of the components and global options of the configuration. The
+// caller must hold c.mu.
+func (c *Config) walkStrings(fn func(kind ComponentKind, key string, s string)) {
+	for _, kind := range componentKinds {
+		for key, cfg := range c.components(kind) {
+			walkValueStrings(cfg, func(s string) { fn(kind, key, s) })
+		}
+	}
+	for key, value := range c.internal.Globals {
+		walkValueStrings(value, func(s string) { fn(KindGlobal, key, s) })
+	}
+}
+
+// walkValueStrings calls fn with every string held by v.
+func walkValueStrings(v any, fn func(s string)) {
+	switch v := v.(type) {
+	case string:
+		fn(v)
+	case map[string]any:
+		for _, item := range v {
+			walkValueStrings(item, fn)
+		}
+	case []any:
+		for _, item := range v {
+			walkValueStrings(item, fn)
+		}
+	case []string:
+		for _, item := range v {
+			fn(item)
+		}
+	}
+}
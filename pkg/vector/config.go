@@ -3,32 +3,108 @@ package vector
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
+	"sync"
 )
 
+// ErrNotFound is returned when a component does not exist in the configuration.
+var ErrNotFound = errors.New("component not found")
+
 // Config is the configuration supplied to vector. It is converted into JSON format before being written to a file.
+//
+// A Config is safe for concurrent use. Component configurations are deep copied on the way in and out, so callers
+// are free to reuse or mutate the maps they pass in or get back.
 type Config struct {
+	mu       sync.RWMutex
 	internal internalConfig
+
+	// fragments records the names of the fragments that supplied each component when the config was merged.
+	fragments map[componentID][]string
+
+	// metadata holds the metadata of components, see SetMetadata.
+	metadata map[componentID]Metadata
+
+	// targetVersion is the vector version the configuration is written for, see SetTargetVersion.
+	targetVersion string
+}
+
+// componentID identifies a component within a configuration.
+type componentID struct {
+	kind ComponentKind
+	key  string
 }
 
 // internalConfig is the internal representation of the vector configuration.
 type internalConfig struct {
-	SecretBackends map[string]map[string]any `json:"secret,omitempty"`
-	Sources        map[string]map[string]any `json:"sources"`
-	Transforms     map[string]map[string]any `json:"transforms,omitempty"`
-	Sinks          map[string]map[string]any `json:"sinks"`
+	SecretBackends   map[string]map[string]any `json:"secret,omitempty"`
+	EnrichmentTables map[string]map[string]any `json:"enrichment_tables,omitempty"`
+	Sources          map[string]map[string]any `json:"sources"`
+	Transforms       map[string]map[string]any `json:"transforms,omitempty"`
+	Sinks            map[string]map[string]any `json:"sinks"`
+	Tests            []Test                    `json:"tests,omitempty"`
+
+	// Globals holds every other top-level key of the configuration, such as global options. They are rendered
+	// alongside the components.
+	Globals map[string]any `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, rendering the global options next to the components.
+func (i internalConfig) MarshalJSON() ([]byte, error) {
+	type plain internalConfig
+	raw, err := json.Marshal(plain(i))
+	if err != nil || len(i.Globals) == 0 {
+		return raw, err
+	}
+
+	document, err := decodeJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range i.Globals {
+		if _, ok := document[key]; !ok {
+			document[key] = value
+		}
+	}
+	return json.Marshal(document)
 }
 
 // NewConfig creates an empty vector configuration.
 func NewConfig() *Config {
 	return &Config{
 		internal: internalConfig{
-			SecretBackends: make(map[string]map[string]any),
-			Sources:        make(map[string]map[string]any),
-			Transforms:     make(map[string]map[string]any),
-			Sinks:          make(map[string]map[string]any),
+			SecretBackends:   make(map[string]map[string]any),
+			EnrichmentTables: make(map[string]map[string]any),
+			Sources:          make(map[string]map[string]any),
+			Transforms:       make(map[string]map[string]any),
+			Sinks:            make(map[string]map[string]any),
+			Tests:            make([]Test, 0),
+			Globals:          make(map[string]any),
+		},
+		fragments: make(map[componentID][]string),
+		metadata:  make(map[componentID]Metadata),
+	}
+}
+
+// Clone returns a deep copy of the configuration.
+func (c *Config) Clone() *Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return &Config{
+		internal: internalConfig{
+			SecretBackends:   copyComponents(c.internal.SecretBackends),
+			EnrichmentTables: copyComponents(c.internal.EnrichmentTables),
+			Sources:          copyComponents(c.internal.Sources),
+			Transforms:       copyComponents(c.internal.Transforms),
+			Sinks:            copyComponents(c.internal.Sinks),
+			Tests:            copyTests(c.internal.Tests),
+			Globals:          copyComponent(c.internal.Globals),
 		},
+		fragments:     copyFragments(c.fragments),
+		metadata:      maps.Clone(c.metadata),
+		targetVersion: c.targetVersion,
 	}
 }
 
@@ -37,42 +113,190 @@ func NewConfig() *Config {
 // The backendName is what Vector will refer to when using the secret backend.
 // https://vector.dev/highlights/2022-07-07-secrets-management/
 func (c *Config) AddSecretBackend(backendName string, cfg map[string]any) {
-	if _, ok := c.internal.SecretBackends[backendName]; ok {
-		panic(fmt.Sprintf("secret backend key '%s' already added to configuration", backendName))
-	}
+	must(c.TryAddSecretBackend(backendName, cfg))
+}
 
-	c.internal.SecretBackends[backendName] = cfg
+// TryAddSecretBackend is AddSecretBackend, returning ErrDuplicateKey instead of panicking.
+func (c *Config) TryAddSecretBackend(backendName string, cfg map[string]any) error {
+	return c.add(KindSecretBackend, backendName, cfg)
 }
 
 // AddSourceUntyped adds the specified configuration as a vector source under key.
 func (c *Config) AddSourceUntyped(key string, cfg map[string]any) {
-	if _, ok := c.internal.Sources[key]; ok {
-		panic(fmt.Sprintf("source key '%s' already added to configuration", key))
-	}
+	must(c.TryAddSourceUntyped(key, cfg))
+}
 
-	c.internal.Sources[key] = cfg
+// TryAddSourceUntyped is AddSourceUntyped, returning ErrDuplicateKey instead of panicking.
+func (c *Config) TryAddSourceUntyped(key string, cfg map[string]any) error {
+	return c.add(KindSource, key, cfg)
 }
 
 // AddTransformUntyped adds the specified configuration as a vector transform under key.
 func (c *Config) AddTransformUntyped(key string, cfg map[string]any) {
-	if _, ok := c.internal.Transforms[key]; ok {
-		panic(fmt.Sprintf("transforms key '%s' already added to configuration", key))
-	}
+	must(c.TryAddTransformUntyped(key, cfg))
+}
 
-	c.internal.Transforms[key] = cfg
+// TryAddTransformUntyped is AddTransformUntyped, returning ErrDuplicateKey instead of panicking.
+func (c *Config) TryAddTransformUntyped(key string, cfg map[string]any) error {
+	return c.add(KindTransform, key, cfg)
 }
 
 // AddSinkUntyped adds the specified configuration as a vector sink under key.
 func (c *Config) AddSinkUntyped(key string, cfg map[string]any) {
-	if _, ok := c.internal.Sinks[key]; ok {
-		panic(fmt.Sprintf("sinks key '%s' already added to configuration", key))
+	must(c.TryAddSinkUntyped(key, cfg))
+}
+
+// TryAddSinkUntyped is AddSinkUntyped, returning ErrDuplicateKey instead of panicking.
+func (c *Config) TryAddSinkUntyped(key string, cfg map[string]any) error {
+	return c.add(KindSink, key, cfg)
+}
+
+// GetSecretBackend returns the secret backend under backendName.
+func (c *Config) GetSecretBackend(backendName string) (map[string]any, bool) {
+	return c.get(KindSecretBackend, backendName)
+}
+
+// GetSource returns the source under key.
+func (c *Config) GetSource(key string) (map[string]any, bool) {
+	return c.get(KindSource, key)
+}
+
+// GetTransform returns the transform under key.
+func (c *Config) GetTransform(key string) (map[string]any, bool) {
+	return c.get(KindTransform, key)
+}
+
+// GetSink returns the sink under key.
+func (c *Config) GetSink(key string) (map[string]any, bool) {
+	return c.get(KindSink, key)
+}
+
+// RemoveSecretBackend removes the secret backend under backendName, returning ErrNotFound if there is none.
+func (c *Config) RemoveSecretBackend(backendName string) error {
+	return c.remove(KindSecretBackend, backendName)
+}
+
+// RemoveSource removes the source under key, returning ErrNotFound if there is none.
+func (c *Config) RemoveSource(key string) error {
+	return c.remove(KindSource, key)
+}
+
+// RemoveTransform removes the transform under key, returning ErrNotFound if there is none.
+func (c *Config) RemoveTransform(key string) error {
+	return c.remove(KindTransform, key)
+}
+
+// RemoveSink removes the sink under key, returning ErrNotFound if there is none.
+func (c *Config) RemoveSink(key string) error {
+	return c.remove(KindSink, key)
+}
+
+// ReplaceSecretBackend replaces the secret backend under backendName, returning ErrNotFound if there is none.
+func (c *Config) ReplaceSecretBackend(backendName string, cfg map[string]any) error {
+	return c.replace(KindSecretBackend, backendName, cfg)
+}
+
+// ReplaceSourceUntyped replaces the source under key, returning ErrNotFound if there is none.
+func (c *Config) ReplaceSourceUntyped(key string, cfg map[string]any) error {
+	return c.replace(KindSource, key, cfg)
+}
+
+// ReplaceTransformUntyped replaces the transform under key, returning ErrNotFound if there is none.
+func (c *Config) ReplaceTransformUntyped(key string, cfg map[string]any) error {
+	return c.replace(KindTransform, key, cfg)
+}
+
+// ReplaceSinkUntyped replaces the sink under key, returning ErrNotFound if there is none.
+func (c *Config) ReplaceSinkUntyped(key string, cfg map[string]any) error {
+	return c.replace(KindSink, key, cfg)
+}
+
+// components returns the components of the given kind. The caller must hold c.mu.
+func (c *Config) components(kind ComponentKind) map[string]map[string]any {
+	switch kind {
+	case KindSecretBackend:
+		return c.internal.SecretBackends
+	case KindEnrichmentTable:
+		return c.internal.EnrichmentTables
+	case KindSource:
+		return c.internal.Sources
+	case KindTransform:
+		return c.internal.Transforms
+	case KindSink:
+		return c.internal.Sinks
+	default:
+		panic(fmt.Sprintf("unknown component kind '%s'", kind))
+	}
+}
+
+// add adds cfg under key, returning ErrDuplicateKey if the key is already in use.
+func (c *Config) add(kind ComponentKind, key string, cfg map[string]any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	components := c.components(kind)
+	if _, ok := components[key]; ok {
+		return fmt.Errorf("%w: %s key '%s' already added to configuration", ErrDuplicateKey, kind, key)
+	}
+
+	components[key] = copyComponent(cfg)
+	return nil
+}
+
+// get returns a copy of the component under key.
+func (c *Config) get(kind ComponentKind, key string) (map[string]any, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cfg, ok := c.components(kind)[key]
+	if !ok {
+		return nil, false
+	}
+	return copyComponent(cfg), true
+}
+
+// remove removes the component under key, returning ErrNotFound if there is none.
+func (c *Config) remove(kind ComponentKind, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	components := c.components(kind)
+	if _, ok := components[key]; !ok {
+		return fmt.Errorf("%w: %s key '%s'", ErrNotFound, kind, key)
+	}
+
+	delete(components, key)
+	delete(c.fragments, componentID{kind: kind, key: key})
+	delete(c.metadata, componentID{kind: kind, key: key})
+	return nil
+}
+
+// replace replaces the component under key, returning ErrNotFound if there is none.
+func (c *Config) replace(kind ComponentKind, key string, cfg map[string]any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	components := c.components(kind)
+	if _, ok := components[key]; !ok {
+		return fmt.Errorf("%w: %s key '%s'", ErrNotFound, kind, key)
 	}
 
-	c.internal.Sinks[key] = cfg
+	components[key] = copyComponent(cfg)
+	return nil
+}
+
+// must panics if err is not nil.
+func must(err error) {
+	if err != nil {
+		panic(err.Error())
+	}
 }
 
 // JSON returns the JSON representation of the configuration.
 func (c *Config) JSON() (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	result := bytes.NewBuffer(nil)
 	if err := json.NewEncoder(result).Encode(c.internal); err != nil {
 		return "", fmt.Errorf("error encoding config: %w", err)
@@ -80,23 +304,26 @@ func (c *Config) JSON() (string, error) {
 	return result.String(), nil
 }
 
-// Sources returns a copy of the current sources.
+// Sources returns a deep copy of the current sources.
 func (c *Config) Sources() map[string]map[string]any {
-	result := make(map[string]map[string]any)
-	maps.Copy(result, c.internal.Sources)
-	return result
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return copyComponents(c.internal.Sources)
 }
 
-// Transforms returns a copy of the current transforms.
+// Transforms returns a deep copy of the current transforms.
 func (c *Config) Transforms() map[string]map[string]any {
-	result := make(map[string]map[string]any)
-	maps.Copy(result, c.internal.Transforms)
-	return result
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return copyComponents(c.internal.Transforms)
 }
 
-// Sinks returns a copy of the current sinks.
+// Sinks returns a deep copy of the current sinks.
 func (c *Config) Sinks() map[string]map[string]any {
-	result := make(map[string]map[string]any)
-	maps.Copy(result, c.internal.Sinks)
-	return result
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return copyComponents(c.internal.Sinks)
 }
@@ -0,0 +1,133 @@
+package vector
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigMutation(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	require.NoError(t, c.TryAddSource("logs", new(KubernetesLogsSource)))
+	require.ErrorIs(t, c.TryAddSourceUntyped("logs", map[string]any{"type": "file"}), ErrDuplicateKey)
+
+	got, ok := c.GetSource("logs")
+	require.True(t, ok)
+	require.Equal(t, map[string]any{"type": "kubernetes_logs"}, got)
+
+	require.NoError(t, c.ReplaceSource("logs", &FileSource{Include: []string{"/var/log/*.log"}}))
+	got, ok = c.GetSource("logs")
+	require.True(t, ok)
+	require.Equal(t, "file", got["type"])
+
+	require.ErrorIs(t, c.ReplaceSinkUntyped("out", map[string]any{"type": "blackhole"}), ErrNotFound)
+	require.ErrorIs(t, c.RemoveTransform("parse"), ErrNotFound)
+
+	require.NoError(t, c.TryAddSecretBackend("vault", map[string]any{"type": "exec"}))
+	require.ErrorIs(t, c.TryAddSecretBackend("vault", map[string]any{"type": "exec"}), ErrDuplicateKey)
+	require.NoError(t, c.RemoveSecretBackend("vault"))
+
+	require.NoError(t, c.RemoveSource("logs"))
+	_, ok = c.GetSource("logs")
+	require.False(t, ok)
+}
+
+func TestAddPanicsOnDuplicate(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddSinkUntyped("out", map[string]any{"type": "blackhole"})
+	require.PanicsWithValue(t, "duplicate component key: sink key 'out' already added to configuration", func() {
+		c.AddSink("out", new(BlackholeSink))
+	})
+}
+
+func TestConfigDeepCopies(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{"logs"}
+	labels := map[string]any{"source": "vector"}
+
+	c := NewConfig()
+	c.AddSinkUntyped("out", map[string]any{
+		"type":   "loki",
+		"inputs": inputs,
+		"labels": labels,
+	})
+
+	// Mutating what was passed in must not change the configuration.
+	inputs[0] = "changed"
+	labels["source"] = "changed"
+
+	sinks := c.Sinks()
+	require.Equal(t, []string{"logs"}, sinks["out"]["inputs"])
+	require.Equal(t, map[string]any{"source": "vector"}, sinks["out"]["labels"])
+
+	// Mutating what was returned must not change the configuration either.
+	returnedInputs, ok := sinks["out"]["inputs"].([]string)
+	require.True(t, ok)
+	returnedInputs[0] = "changed"
+	returnedLabels, ok := sinks["out"]["labels"].(map[string]any)
+	require.True(t, ok)
+	returnedLabels["source"] = "changed"
+
+	got, ok := c.GetSink("out")
+	require.True(t, ok)
+	require.Equal(t, []string{"logs"}, got["inputs"])
+	require.Equal(t, map[string]any{"source": "vector"}, got["labels"])
+}
+
+func TestConfigClone(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddSource("logs", new(KubernetesLogsSource))
+	c.AddSink("out", &BlackholeSink{Inputs: []string{"logs"}})
+
+	clone := c.Clone()
+	require.NoError(t, clone.RemoveSink("out"))
+	clone.AddSink("other", &BlackholeSink{Inputs: []string{"logs"}})
+
+	want, err := c.JSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"sources": {"logs": {"type": "kubernetes_logs"}},
+		"sinks": {"out": {"type": "blackhole", "inputs": ["logs"]}}
+	}`, want)
+}
+
+func TestConfigConcurrentUse(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddSource("logs", new(KubernetesLogsSource))
+
+	errs := make(chan error, 50*3)
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			key := fmt.Sprintf("out_%d", i)
+			errs <- c.TryAddSink(key, &BlackholeSink{Inputs: []string{"logs"}})
+			_, err := c.JSON()
+			errs <- err
+			_ = c.Clone()
+			_ = c.Validate()
+			errs <- c.ReplaceSink(key, &BlackholeSink{Inputs: []string{"logs"}})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Len(t, c.Sinks(), 50)
+	require.NoError(t, c.Validate())
+}
@@ -0,0 +1,90 @@
+package vector
+
+import "reflect"
+
+// copyComponents returns a deep copy of the components.
+func copyComponents(components map[string]map[string]any) map[string]map[string]any {
+	result := make(map[string]map[string]any, len(components))
+	for key, cfg := range components {
+		result[key] = copyComponent(cfg)
+	}
+	return result
+}
+
+// copyComponent returns a deep copy of a single component configuration.
+func copyComponent(cfg map[string]any) map[string]any {
+	if cfg == nil {
+		return nil
+	}
+
+	result := make(map[string]any, len(cfg))
+	for k, v := range cfg {
+		result[k] = copyValue(v)
+	}
+	return result
+}
+
+// copyValue returns a deep copy of v, copying any nested maps and slices.
+func copyValue(v any) any {
+	switch v := v.(type) {
+	case nil, string, bool, int, int64, float64:
+		return v
+	case map[string]any:
+		return copyComponent(v)
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = copyValue(item)
+		}
+		return result
+	case []string:
+		return append([]string(nil), v...)
+	}
+
+	return copyReflect(reflect.ValueOf(v)).Interface()
+}
+
+// copyReflect deep copies maps, slices and pointers of arbitrary types.
+func copyReflect(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), copyElem(iter.Value(), v.Type().Elem()))
+		}
+		return result
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			result.Index(i).Set(copyElem(v.Index(i), v.Type().Elem()))
+		}
+		return result
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.New(v.Type().Elem())
+		result.Elem().Set(copyElem(v.Elem(), v.Type().Elem()))
+		return result
+	default:
+		return v
+	}
+}
+
+// copyElem deep copies an element of a container, keeping it assignable to typ.
+func copyElem(v reflect.Value, typ reflect.Type) reflect.Value {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Zero(typ)
+		}
+		return reflect.ValueOf(copyValue(v.Interface()))
+	}
+	return copyReflect(v)
+}
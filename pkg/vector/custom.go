@@ -0,0 +1,79 @@
+package vector
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Component is a typed configuration of any kind of component. It lets callers define their own structs for vector
+// components this package does not model, marshalled through their json struct tags.
+type Component interface {
+	// Kind returns the kind of the component.
+	Kind() ComponentKind
+
+	// Type returns the vector type of the component.
+	Type() string
+}
+
+// Custom returns cfg as a component of the given kind and vector type, for structs that do not implement
+// Component themselves.
+func Custom[T any](kind ComponentKind, componentType string, cfg T) Component {
+	return &customComponent[T]{
+		kind:          kind,
+		componentType: componentType,
+		cfg:           cfg,
+	}
+}
+
+// customComponent is a component built by Custom.
+type customComponent[T any] struct {
+	kind          ComponentKind
+	componentType string
+	cfg           T
+}
+
+// Kind implements Component.
+func (c *customComponent[T]) Kind() ComponentKind { return c.kind }
+
+// Type implements Component.
+func (c *customComponent[T]) Type() string { return c.componentType }
+
+// MarshalJSON implements json.Marshaler, encoding the wrapped configuration.
+func (c *customComponent[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.cfg)
+}
+
+// AddComponent adds the specified typed component under key, as the kind of component it returns.
+func (c *Config) AddComponent(key string, component Component) {
+	must(c.TryAddComponent(key, component))
+}
+
+// TryAddComponent is AddComponent, returning an error instead of panicking.
+func (c *Config) TryAddComponent(key string, component Component) error {
+	cfg, err := customComponentMap(component)
+	if err != nil {
+		return err
+	}
+	return c.add(component.Kind(), key, cfg)
+}
+
+// ReplaceComponent replaces the component of the same kind under key, returning ErrNotFound if there is none.
+func (c *Config) ReplaceComponent(key string, component Component) error {
+	cfg, err := customComponentMap(component)
+	if err != nil {
+		return err
+	}
+	return c.replace(component.Kind(), key, cfg)
+}
+
+// customComponentMap converts a Component into the untyped representation stored in the configuration.
+func customComponentMap(component Component) (map[string]any, error) {
+	if !slices.Contains(componentKinds, component.Kind()) {
+		return nil, fmt.Errorf("unknown component kind '%s'", component.Kind())
+	}
+	if component.Type() == "" {
+		return nil, fmt.Errorf("%s component has no type", component.Kind())
+	}
+	return componentMap(component.Type(), component)
+}
@@ -0,0 +1,49 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// nats is a sink defined outside the package, implementing Component itself.
+type nats struct {
+	Inputs  []string `json:"inputs"`
+	Subject string   `json:"subject"`
+	URL     string   `json:"url"`
+}
+
+func (*nats) Kind() ComponentKind { return KindSink }
+func (*nats) Type() string        { return "nats" }
+
+// demoLogs has no methods and is registered through Custom.
+type demoLogs struct {
+	Format   string `json:"format"`
+	Interval int    `json:"interval,omitempty"`
+}
+
+func TestCustomComponents(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddComponent("demo", Custom(KindSource, "demo_logs", demoLogs{Format: "json"}))
+	c.AddComponent("nats", &nats{Inputs: []string{"demo"}, Subject: "logs", URL: "nats://nats:4222"})
+
+	got, err := c.JSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"sources": {"demo": {"type": "demo_logs", "format": "json"}},
+		"sinks": {"nats": {"type": "nats", "inputs": ["demo"], "subject": "logs", "url": "nats://nats:4222"}}
+	}`, got)
+	require.NoError(t, c.Validate())
+
+	require.ErrorIs(t, c.TryAddComponent("demo", Custom(KindSource, "demo_logs", demoLogs{})), ErrDuplicateKey)
+	require.NoError(t, c.ReplaceComponent("demo", Custom(KindSource, "demo_logs", demoLogs{Format: "syslog", Interval: 1})))
+	src, ok := c.GetSource("demo")
+	require.True(t, ok)
+	require.Equal(t, "syslog", src["format"])
+	require.ErrorIs(t, c.ReplaceComponent("other", Custom(KindSource, "demo_logs", demoLogs{})), ErrNotFound)
+
+	require.EqualError(t, c.TryAddComponent("x", Custom(KindGlobal, "demo_logs", demoLogs{})), "unknown component kind 'global option'")
+	require.EqualError(t, c.TryAddComponent("x", Custom(KindSource, "", demoLogs{})), "source component has no type")
+}
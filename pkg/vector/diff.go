@@ -0,0 +1,290 @@
+package vector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// redacted replaces secret looking values in diffs.
+const redacted = "<redacted>"
+
+// secretOptionPattern matches the names of options whose values should not be shown in diffs.
+var secretOptionPattern = regexp.MustCompile(`(?i)(password|passwd|token|secret|api_?key|private_key|key_pass|access_key|credentials)`)
+
+// secretTablePattern matches the names of tables whose values should not be shown in diffs, as HTTP headers often
+// carry credentials, e.g. "Authorization" or "Cookie".
+var secretTablePattern = regexp.MustCompile(`(?i)^headers$`)
+
+// userinfoPattern matches the scheme and userinfo of URLs, e.g. "https://user:password@".
+var userinfoPattern = regexp.MustCompile(`([A-Za-z][A-Za-z0-9+.-]*://)[^/?#@\s]+@`)
+
+// ChangeType is the type of change made to a component.
+type ChangeType string
+
+// Types of change reported by Diff.
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+// FieldChange is a change to a single field of a modified component. Secret looking values are redacted.
+type FieldChange struct {
+	// Path is the JSON pointer of the field, relative to the component.
+	Path string `json:"path"`
+
+	// Old is the previous value, nil if the field was added.
+	Old any `json:"old,omitempty"`
+
+	// New is the new value, nil if the field was removed.
+	New any `json:"new,omitempty"`
+}
+
+// ComponentChange is a change to a single component or global option.
+type ComponentChange struct {
+	Kind ComponentKind `json:"kind"`
+	Key  string        `json:"key"`
+	Type ChangeType    `json:"type"`
+
+	// Fields holds the changed fields of a modified component.
+	Fields []FieldChange `json:"fields,omitempty"`
+
+	// old and new are the redacted component before and after the change, used for the unified rendering.
+	old any
+	new any
+}
+
+// ConfigDiff is the structural difference between two configurations.
+type ConfigDiff struct {
+	Changes []ComponentChange `json:"changes"`
+}
+
+// Diff returns the structural difference between two configurations. A nil configuration is treated as empty.
+//
+// Reordering lists whose order vector ignores, such as inputs, is not reported as a change.
+func Diff(oldCfg, newCfg *Config) (*ConfigDiff, error) {
+	oldDoc, err := diffDocument(oldCfg)
+	if err != nil {
+		return nil, err
+	}
+	newDoc, err := diffDocument(newCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ConfigDiff{
+		Changes: make([]ComponentChange, 0),
+	}
+	for _, top := range sortedKeys(mergeKeys(oldDoc, newDoc)) {
+		kind, isComponents := documentKinds[top]
+		if !isComponents {
+			result.add(KindGlobal, top, oldDoc[top], newDoc[top])
+			continue
+		}
+
+		oldComponents, _ := oldDoc[top].(map[string]any)
+		newComponents, _ := newDoc[top].(map[string]any)
+		for _, key := range sortedKeys(mergeKeys(oldComponents, newComponents)) {
+			result.add(kind, key, oldComponents[key], newComponents[key])
+		}
+	}
+
+	return result, nil
+}
+
+// diffDocument returns the document of cfg, or an empty document if cfg is nil.
+func diffDocument(cfg *Config) (map[string]any, error) {
+	if cfg == nil {
+		return make(map[string]any), nil
+	}
+	return cfg.document()
+}
+
+// add records the change between oldValue and newValue, if any.
+func (d *ConfigDiff) add(kind ComponentKind, key string, oldValue, newValue any) {
+	change := ComponentChange{
+		Kind: kind,
+		Key:  key,
+		old:  redact(key, oldValue),
+		new:  redact(key, newValue),
+	}
+
+	switch {
+	case oldValue == nil && newValue == nil:
+		return
+	case oldValue == nil:
+		change.Type = ChangeAdded
+	case newValue == nil:
+		change.Type = ChangeRemoved
+	default:
+		change.Fields = diffValues("", "", oldValue, newValue, false)
+		if len(change.Fields) == 0 {
+			return
+		}
+		change.Type = ChangeModified
+	}
+
+	d.Changes = append(d.Changes, change)
+}
+
+// Empty reports whether the configurations were the same.
+func (d *ConfigDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// Count returns the number of changes of the given type.
+func (d *ConfigDiff) Count(t ChangeType) int {
+	count := 0
+	for _, change := range d.Changes {
+		if change.Type == t {
+			count++
+		}
+	}
+	return count
+}
+
+// String returns a human-readable unified diff of the changes.
+func (d *ConfigDiff) String() string {
+	result := new(strings.Builder)
+	for _, change := range d.Changes {
+		name := fmt.Sprintf("%s/%s", change.Kind, change.Key)
+		from, to := "a/"+name, "b/"+name
+		if change.Type == ChangeAdded {
+			from = "/dev/null"
+		}
+		if change.Type == ChangeRemoved {
+			to = "/dev/null"
+		}
+
+		unified, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        renderLines(change.old),
+			B:        renderLines(change.new),
+			FromFile: from,
+			ToFile:   to,
+			Context:  3,
+		})
+		if err != nil {
+			// Writing to a strings.Builder cannot fail, so neither can this.
+			continue
+		}
+		result.WriteString(unified)
+	}
+	return result.String()
+}
+
+// diffValues returns the changed fields between two values found at the JSON pointer path. The key is the name
+// of the option holding the values and secret is set if they are held by a secret table, see secretTablePattern.
+func diffValues(path, key string, oldValue, newValue any, secret bool) []FieldChange {
+	oldMap, oldIsMap := oldValue.(map[string]any)
+	newMap, newIsMap := newValue.(map[string]any)
+	if oldIsMap && newIsMap {
+		secret = secret || secretTablePattern.MatchString(key)
+		changes := make([]FieldChange, 0)
+		for _, k := range sortedKeys(mergeKeys(oldMap, newMap)) {
+			changes = append(changes, diffValues(path+"/"+escapePointer(k), k, oldMap[k], newMap[k], secret)...)
+		}
+		return changes
+	}
+
+	if equalValues(key, oldValue, newValue) {
+		return nil
+	}
+
+	return []FieldChange{{
+		Path: path,
+		Old:  redactValue(key, oldValue, secret),
+		New:  redactValue(key, newValue, secret),
+	}}
+}
+
+// equalValues reports whether two values of the option key are the same to vector.
+func equalValues(key string, a, b any) bool {
+	bufA, bufB := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+	if writeCanonical(bufA, key, a) != nil || writeCanonical(bufB, key, b) != nil {
+		return false
+	}
+	return bufA.String() == bufB.String()
+}
+
+// redact returns v with the values of secret looking options and of secret tables replaced, and the userinfo of
+// URLs removed. The key is the name of the option holding v.
+func redact(key string, v any) any {
+	return redactValue(key, v, false)
+}
+
+// redactValue is redact for a value held by a secret table if secret is set.
+func redactValue(key string, v any, secret bool) any {
+	if v == nil {
+		return nil
+	}
+
+	if (secret || secretOptionPattern.MatchString(key)) && !isSecretReference(v) {
+		if _, ok := v.(map[string]any); !ok {
+			return redacted
+		}
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		secret = secret || secretTablePattern.MatchString(key)
+		result := make(map[string]any, len(v))
+		for k, item := range v {
+			result[k] = redactValue(k, item, secret)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = redactValue(key, item, secret)
+		}
+		return result
+	case string:
+		return userinfoPattern.ReplaceAllString(v, "${1}")
+	default:
+		return v
+	}
+}
+
+// isSecretReference reports whether v only refers to a secret rather than holding it, e.g. "SECRET[vault.key]".
+func isSecretReference(v any) bool {
+	s, ok := v.(string)
+	return ok && s != "" && secretReferencePattern.FindString(s) == s
+}
+
+// renderLines renders v as indented JSON lines for the unified diff.
+func renderLines(v any) []string {
+	if v == nil {
+		return nil
+	}
+
+	buf := bytes.NewBuffer(nil)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return difflib.SplitLines(fmt.Sprintf("%v", v))
+	}
+	return difflib.SplitLines(strings.TrimSuffix(buf.String(), "\n"))
+}
+
+// escapePointer escapes a key for use in a JSON pointer.
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+// mergeKeys returns a set of the keys of both maps.
+func mergeKeys[V any](a, b map[string]V) map[string]struct{} {
+	result := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		result[k] = struct{}{}
+	}
+	for k := range b {
+		result[k] = struct{}{}
+	}
+	return result
+}
@@ -0,0 +1,149 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	oldCfg := NewConfig()
+	oldCfg.AddSource("logs", new(KubernetesLogsSource))
+	oldCfg.AddSource("metrics", new(InternalMetricsSource))
+	oldCfg.AddSink("out", &HTTPSink{
+		Inputs:   []string{"logs", "metrics"},
+		URI:      "http://collector",
+		Encoding: Encoding{Codec: "json"},
+		Auth:     &HTTPAuth{Strategy: "basic", Username: "vector", Password: "hunter2"},
+	})
+
+	newCfg := NewConfig()
+	newCfg.AddSource("logs", new(KubernetesLogsSource))
+	newCfg.AddSource("host", new(HostMetricsSource))
+	newCfg.AddSink("out", &HTTPSink{
+		Inputs:   []string{"host", "logs"},
+		URI:      "http://collector",
+		Encoding: Encoding{Codec: "json"},
+		Auth:     &HTTPAuth{Strategy: "basic", Username: "vector", Password: "correct-horse"},
+	})
+
+	diff, err := Diff(oldCfg, newCfg)
+	require.NoError(t, err)
+	require.Equal(t, []ComponentChange{
+		{
+			Kind: KindSink,
+			Key:  "out",
+			Type: ChangeModified,
+			Fields: []FieldChange{
+				{Path: "/auth/password", Old: redacted, New: redacted},
+				{Path: "/inputs", Old: []any{"logs", "metrics"}, New: []any{"host", "logs"}},
+			},
+		},
+		{Kind: KindSource, Key: "host", Type: ChangeAdded},
+		{Kind: KindSource, Key: "metrics", Type: ChangeRemoved},
+	}, withoutRendering(diff.Changes))
+	require.Equal(t, 1, diff.Count(ChangeAdded))
+
+	require.Equal(t, `--- a/sink/out
++++ b/sink/out
+@@ -8,8 +8,8 @@
+     "codec": "json"
+   },
+   "inputs": [
+-    "logs",
+-    "metrics"
++    "host",
++    "logs"
+   ],
+   "type": "http",
+   "uri": "http://collector"
+--- /dev/null
++++ b/source/host
+@@ -0,0 +1,3 @@
++{
++  "type": "host_metrics"
++}
+--- a/source/metrics
++++ /dev/null
+@@ -1,3 +0,0 @@
+-{
+-  "type": "internal_metrics"
+-}
+`, diff.String())
+}
+
+func TestDiffIgnoresInsignificantChanges(t *testing.T) {
+	t.Parallel()
+
+	oldCfg, err := ParseJSON([]byte(`{
+		"data_dir": "/var/lib/vector",
+		"sources": {"a": {"type": "internal_metrics"}, "b": {"type": "host_metrics", "scrape_interval_secs": 15.0}},
+		"sinks": {"out": {"type": "blackhole", "inputs": ["a", "b"]}}
+	}`))
+	require.NoError(t, err)
+
+	newCfg := oldCfg.Clone()
+	require.NoError(t, newCfg.ReplaceSinkUntyped("out", map[string]any{"type": "blackhole", "inputs": []string{"b", "a"}}))
+	require.NoError(t, newCfg.ReplaceSource("b", &HostMetricsSource{ScrapeIntervalSecs: 15}))
+
+	diff, err := Diff(oldCfg, newCfg)
+	require.NoError(t, err)
+	require.True(t, diff.Empty())
+
+	diff, err = Diff(nil, newCfg)
+	require.NoError(t, err)
+	require.Equal(t, 4, diff.Count(ChangeAdded))
+	require.Equal(t, KindGlobal, diff.Changes[0].Kind)
+}
+
+func TestDiffRedactsHeadersAndUserinfo(t *testing.T) {
+	t.Parallel()
+
+	sink := func(token, password string) *Config {
+		c := NewConfig()
+		c.AddSource("logs", new(KubernetesLogsSource))
+		c.AddSink("out", &HTTPSink{
+			Inputs:   []string{"logs"},
+			URI:      "https://vector:" + password + "@collector/logs",
+			Encoding: Encoding{Codec: "json"},
+			Request: &Request{Headers: map[string]string{
+				"Authorization": "Bearer " + token,
+				"X-Scope-OrgID": token,
+			}},
+		})
+		return c
+	}
+
+	diff, err := Diff(sink("old-token-value", "hunter2"), sink("new-token-value", "correct-horse"))
+	require.NoError(t, err)
+	require.Equal(t, []ComponentChange{{
+		Kind: KindSink,
+		Key:  "out",
+		Type: ChangeModified,
+		Fields: []FieldChange{
+			{Path: "/request/headers/Authorization", Old: redacted, New: redacted},
+			{Path: "/request/headers/X-Scope-OrgID", Old: redacted, New: redacted},
+			{Path: "/uri", Old: "https://collector/logs", New: "https://collector/logs"},
+		},
+	}}, withoutRendering(diff.Changes))
+
+	added, err := Diff(nil, sink("new-token-value", "correct-horse"))
+	require.NoError(t, err)
+	rendered := added.String()
+	require.NotContains(t, rendered, "new-token-value")
+	require.NotContains(t, rendered, "correct-horse")
+	require.Contains(t, rendered, `"Authorization": "<redacted>"`)
+	require.Contains(t, rendered, `"uri": "https://collector/logs"`)
+}
+
+// withoutRendering strips the unexported rendering state so changes can be compared.
+func withoutRendering(changes []ComponentChange) []ComponentChange {
+	result := make([]ComponentChange, 0, len(changes))
+	for _, change := range changes {
+		change.old, change.new = nil, nil
+		result = append(result, change)
+	}
+	return result
+}
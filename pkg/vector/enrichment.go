@@ -0,0 +1,141 @@
+package vector
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// enrichmentTablePattern matches the VRL functions reading an enrichment table, capturing the table name.
+var enrichmentTablePattern = regexp.MustCompile(`\b(?:get|find)_enrichment_table_records?!?\s*\(\s*(?:table\s*:\s*)?"([^"]+)"`)
+
+// EnrichmentTable is a typed vector enrichment table configuration.
+type EnrichmentTable interface {
+	// EnrichmentTableType returns the vector type of the enrichment table.
+	EnrichmentTableType() string
+}
+
+// AddEnrichmentTable adds the specified typed enrichment table under key.
+//
+// The key is the table name used by the get_enrichment_table_record and find_enrichment_table_records functions.
+func (c *Config) AddEnrichmentTable(key string, table EnrichmentTable) {
+	must(c.TryAddEnrichmentTable(key, table))
+}
+
+// TryAddEnrichmentTable is AddEnrichmentTable, returning an error instead of panicking.
+func (c *Config) TryAddEnrichmentTable(key string, table EnrichmentTable) error {
+	cfg, err := componentMap(table.EnrichmentTableType(), table)
+	if err != nil {
+		return err
+	}
+	return c.TryAddEnrichmentTableUntyped(key, cfg)
+}
+
+// AddEnrichmentTableUntyped adds the specified configuration as an enrichment table under key.
+func (c *Config) AddEnrichmentTableUntyped(key string, cfg map[string]any) {
+	must(c.TryAddEnrichmentTableUntyped(key, cfg))
+}
+
+// TryAddEnrichmentTableUntyped is AddEnrichmentTableUntyped, returning ErrDuplicateKey instead of panicking.
+func (c *Config) TryAddEnrichmentTableUntyped(key string, cfg map[string]any) error {
+	return c.add(KindEnrichmentTable, key, cfg)
+}
+
+// GetEnrichmentTable returns the enrichment table under key.
+func (c *Config) GetEnrichmentTable(key string) (map[string]any, bool) {
+	return c.get(KindEnrichmentTable, key)
+}
+
+// RemoveEnrichmentTable removes the enrichment table under key, returning ErrNotFound if there is none.
+func (c *Config) RemoveEnrichmentTable(key string) error {
+	return c.remove(KindEnrichmentTable, key)
+}
+
+// ReplaceEnrichmentTable replaces the enrichment table under key with a typed table, returning ErrNotFound if
+// there is none.
+func (c *Config) ReplaceEnrichmentTable(key string, table EnrichmentTable) error {
+	cfg, err := componentMap(table.EnrichmentTableType(), table)
+	if err != nil {
+		return err
+	}
+	return c.ReplaceEnrichmentTableUntyped(key, cfg)
+}
+
+// ReplaceEnrichmentTableUntyped replaces the enrichment table under key, returning ErrNotFound if there is none.
+func (c *Config) ReplaceEnrichmentTableUntyped(key string, cfg map[string]any) error {
+	return c.replace(KindEnrichmentTable, key, cfg)
+}
+
+// EnrichmentTables returns a copy of all the enrichment tables of the configuration.
+func (c *Config) EnrichmentTables() map[string]map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return copyComponents(c.internal.EnrichmentTables)
+}
+
+// FileEnrichmentTable loads a CSV file as an enrichment table.
+//
+// https://vector.dev/docs/reference/configuration/global-options/#enrichment_tables.file
+type FileEnrichmentTable struct {
+	File   *EnrichmentFile   `json:"file,omitempty"`
+	Schema map[string]string `json:"schema,omitempty"`
+}
+
+// EnrichmentTableType implements EnrichmentTable.
+func (*FileEnrichmentTable) EnrichmentTableType() string { return "file" }
+
+// EnrichmentFile is the file backing a FileEnrichmentTable.
+type EnrichmentFile struct {
+	Encoding *EnrichmentFileEncoding `json:"encoding,omitempty"`
+	Path     string                  `json:"path"`
+}
+
+// EnrichmentFileEncoding configures how an enrichment file is decoded. Type defaults to "csv".
+type EnrichmentFileEncoding struct {
+	Delimiter      string `json:"delimiter,omitempty"`
+	IncludeHeaders *bool  `json:"include_headers,omitempty"`
+	Type           string `json:"type"`
+}
+
+// MarshalJSON implements json.Marshaler, defaulting Type to "csv".
+func (e EnrichmentFileEncoding) MarshalJSON() ([]byte, error) {
+	if e.Type == "" {
+		e.Type = "csv"
+	}
+
+	type encoding EnrichmentFileEncoding
+	return json.Marshal(encoding(e))
+}
+
+// GeoIPEnrichmentTable loads a MaxMind GeoIP2 or GeoLite2 database as an enrichment table.
+//
+// https://vector.dev/docs/reference/configuration/global-options/#enrichment_tables.geoip
+type GeoIPEnrichmentTable struct {
+	Locale string `json:"locale,omitempty"`
+	Path   string `json:"path"`
+}
+
+// EnrichmentTableType implements EnrichmentTable.
+func (*GeoIPEnrichmentTable) EnrichmentTableType() string { return "geoip" }
+
+// MemoryEnrichmentTable is an in-memory key/value enrichment table.
+//
+// https://vector.dev/docs/reference/configuration/global-options/#enrichment_tables.memory
+type MemoryEnrichmentTable struct {
+	FlushInterval int `json:"flush_interval,omitempty"`
+	MaxByteSize   int `json:"max_byte_size,omitempty"`
+	ScanInterval  int `json:"scan_interval,omitempty"`
+	TTL           int `json:"ttl,omitempty"`
+}
+
+// EnrichmentTableType implements EnrichmentTable.
+func (*MemoryEnrichmentTable) EnrichmentTableType() string { return "memory" }
+
+// enrichmentTableReferences returns the names of the enrichment tables read by a remap source.
+func enrichmentTableReferences(source string) []string {
+	result := make([]string, 0)
+	for _, match := range enrichmentTablePattern.FindAllStringSubmatch(source, -1) {
+		result = append(result, match[1])
+	}
+	return result
+}
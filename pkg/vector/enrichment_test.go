@@ -0,0 +1,79 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrichmentTables(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddEnrichmentTable("hosts", &FileEnrichmentTable{
+		File: &EnrichmentFile{
+			Path:     "/etc/vector/hosts.csv",
+			Encoding: &EnrichmentFileEncoding{Delimiter: ";"},
+		},
+		Schema: map[string]string{"ip": "string"},
+	})
+	c.AddEnrichmentTable("geo", &GeoIPEnrichmentTable{Path: "/etc/vector/GeoLite2-City.mmdb"})
+	c.AddEnrichmentTableUntyped("cache", map[string]any{"type": "memory", "ttl": 60})
+	c.AddSource("logs", new(KubernetesLogsSource))
+	c.AddTransform("enrich", &RemapTransform{
+		Inputs: []string{"logs"},
+		Source: `.host = get_enrichment_table_record!("hosts", {"ip": .ip})`,
+	})
+	c.AddSink("out", &BlackholeSink{Inputs: []string{"enrich"}})
+
+	got, err := c.JSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"enrichment_tables": {
+			"hosts": {
+				"type": "file",
+				"file": {"path": "/etc/vector/hosts.csv", "encoding": {"type": "csv", "delimiter": ";"}},
+				"schema": {"ip": "string"}
+			},
+			"geo": {"type": "geoip", "path": "/etc/vector/GeoLite2-City.mmdb"},
+			"cache": {"type": "memory", "ttl": 60}
+		},
+		"sources": {"logs": {"type": "kubernetes_logs"}},
+		"transforms": {"enrich": {"type": "remap", "inputs": ["logs"], "source": ".host = get_enrichment_table_record!(\"hosts\", {\"ip\": .ip})"}},
+		"sinks": {"out": {"type": "blackhole", "inputs": ["enrich"]}}
+	}`, got)
+	require.NoError(t, c.Validate())
+
+	parsed, err := ParseJSON([]byte(got))
+	require.NoError(t, err)
+	table, ok := parsed.GetEnrichmentTable("geo")
+	require.True(t, ok)
+	require.Equal(t, "geoip", table["type"])
+
+	require.ErrorIs(t, c.TryAddEnrichmentTableUntyped("geo", map[string]any{"type": "geoip"}), ErrDuplicateKey)
+	require.NoError(t, c.RemoveEnrichmentTable("hosts"))
+	require.ErrorIs(t, c.RemoveEnrichmentTable("hosts"), ErrNotFound)
+}
+
+func TestValidateEnrichmentTableReferences(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddEnrichmentTable("geo", &GeoIPEnrichmentTable{Path: "/etc/vector/GeoLite2-City.mmdb"})
+	c.AddSource("logs", new(KubernetesLogsSource))
+	c.AddTransform("enrich", &RemapTransform{
+		Inputs: []string{"logs"},
+		Source: `
+.geo = get_enrichment_table_record!("geo", {"ip": .ip})
+.owner = get_enrichment_table_record("owners", {"host": .host}) ?? {}
+.teams = find_enrichment_table_records!(table: "teams", condition: {"name": .team})
+.again = get_enrichment_table_record!("owners", {"host": .host})
+`,
+	})
+	c.AddSink("out", &BlackholeSink{Inputs: []string{"enrich"}})
+
+	err := c.Validate()
+	require.ErrorIs(t, err, ErrUnknownEnrichmentTable)
+	require.EqualError(t, err, `transform "enrich": enrichment table is not defined: 'owners'`+"\n"+
+		`transform "enrich": enrichment table is not defined: 'teams'`)
+}
@@ -0,0 +1,227 @@
+package vector
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ErrEnvVarUnset is returned by Interpolate when a required environment variable is unset or empty.
+var ErrEnvVarUnset = errors.New("required environment variable is not set")
+
+// envVarPattern matches the environment variable references vector interpolates when loading a configuration,
+// as well as the "$$" escape for a literal dollar sign.
+var envVarPattern = regexp.MustCompile(`\$\$|\$([[:word:].]+)|\$\{([[:word:].]+)(?:(:?[-?])([^}]*))?\}`)
+
+// EnvVar is a reference to an environment variable found in a configuration.
+type EnvVar struct {
+	// Name is the name of the variable.
+	Name string
+
+	// Default is the value used when the variable is not set, nil if the reference has no default.
+	Default *string
+
+	// Required reports whether vector refuses to load the configuration when the variable is not set, as with
+	// "${VAR:?message}".
+	Required bool
+
+	// Message is the error message of a required reference.
+	Message string
+
+	// AllowEmpty reports whether an empty value counts as set. It is false for the ":-" and ":?" forms.
+	AllowEmpty bool
+}
+
+// HasDefault reports whether the reference can be resolved without the variable being set.
+func (v EnvVar) HasDefault() bool {
+	return v.Default != nil
+}
+
+// EnvVars returns every environment variable reference in the configuration, sorted by name. A variable referenced
+// the same way several times is listed once.
+func (c *Config) EnvVars() []EnvVar {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]EnvVar, 0)
+	c.walkStrings(func(_ ComponentKind, _ string, s string) {
+		for _, match := range envVarPattern.FindAllStringSubmatch(s, -1) {
+			ref, ok := parseEnvVar(match)
+			if ok && !slices.ContainsFunc(result, ref.equal) {
+				result = append(result, ref)
+			}
+		}
+	})
+
+	slices.SortStableFunc(result, func(a, b EnvVar) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return result
+}
+
+// EnvVarNames returns the names of the environment variables referenced by the configuration, sorted.
+func (c *Config) EnvVarNames() []string {
+	result := make([]string, 0)
+	for _, ref := range c.EnvVars() {
+		if !slices.Contains(result, ref.Name) {
+			result = append(result, ref.Name)
+		}
+	}
+	return result
+}
+
+// Interpolate returns a copy of the configuration with environment variable references replaced by their values
+// in env, the way vector does when loading the configuration. "$$" is replaced by a literal "$". Unset variables
+// without a default are replaced by an empty string, unless the reference is required, which is an error.
+//
+// Interpolate is meant for tests and previews, the rendered configuration should keep its references.
+func (c *Config) Interpolate(env map[string]string) (*Config, error) {
+	result := c.Clone()
+
+	result.mu.Lock()
+	defer result.mu.Unlock()
+
+	errs := make([]error, 0)
+	for _, kind := range componentKinds {
+		components := result.components(kind)
+		for _, key := range sortedKeys(components) {
+			value, err := interpolateValue(components[key], env)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s '%s': %w", kind, key, err))
+				continue
+			}
+			components[key], _ = value.(map[string]any)
+		}
+	}
+	for _, key := range sortedKeys(result.internal.Globals) {
+		value, err := interpolateValue(result.internal.Globals[key], env)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s '%s': %w", KindGlobal, key, err))
+			continue
+		}
+		result.internal.Globals[key] = value
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// EscapeEnv escapes the dollar signs of s so vector does not interpolate them.
+func EscapeEnv(s string) string {
+	return strings.ReplaceAll(s, "$", "$$")
+}
+
+// parseEnvVar returns the reference of an envVarPattern match, or false if the match is the "$$" escape.
+func parseEnvVar(match []string) (EnvVar, bool) {
+	switch {
+	case match[1] != "":
+		return EnvVar{Name: match[1], AllowEmpty: true}, true
+	case match[2] != "":
+	default:
+		return EnvVar{}, false
+	}
+
+	ref := EnvVar{
+		Name:       match[2],
+		AllowEmpty: !strings.HasPrefix(match[3], ":"),
+	}
+	switch strings.TrimPrefix(match[3], ":") {
+	case "-":
+		ref.Default = &match[4]
+	case "?":
+		ref.Required = true
+		ref.Message = match[4]
+	default:
+		ref.AllowEmpty = true
+	}
+	return ref, true
+}
+
+// equal reports whether two references are the same.
+func (v EnvVar) equal(other EnvVar) bool {
+	return v.Name == other.Name &&
+		v.Required == other.Required &&
+		v.Message == other.Message &&
+		v.AllowEmpty == other.AllowEmpty &&
+		(v.Default == nil) == (other.Default == nil) &&
+		(v.Default == nil || *v.Default == *other.Default)
+}
+
+// resolve returns the value of the reference in env.
+func (v EnvVar) resolve(env map[string]string) (string, error) {
+	value, ok := env[v.Name]
+	if ok && (value != "" || v.AllowEmpty) {
+		return value, nil
+	}
+
+	switch {
+	case v.Default != nil:
+		return *v.Default, nil
+	case v.Required && v.Message != "":
+		return "", fmt.Errorf("%w: '%s': %s", ErrEnvVarUnset, v.Name, v.Message)
+	case v.Required:
+		return "", fmt.Errorf("%w: '%s'", ErrEnvVarUnset, v.Name)
+	default:
+		return "", nil
+	}
+}
+
+// interpolateValue replaces the environment variable references in the strings of v.
+func interpolateValue(v any, env map[string]string) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return interpolateString(v, env)
+	case map[string]any:
+		for k, item := range v {
+			value, err := interpolateValue(item, env)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = value
+		}
+		return v, nil
+	case []any:
+		for i, item := range v {
+			value, err := interpolateValue(item, env)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = value
+		}
+		return v, nil
+	case []string:
+		for i, item := range v {
+			value, err := interpolateString(item, env)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = value
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+// interpolateString replaces the environment variable references in s.
+func interpolateString(s string, env map[string]string) (string, error) {
+	errs := make([]error, 0)
+	result := envVarPattern.ReplaceAllStringFunc(s, func(match string) string {
+		ref, ok := parseEnvVar(envVarPattern.FindStringSubmatch(match))
+		if !ok {
+			return "$"
+		}
+
+		value, err := ref.resolve(env)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		return value
+	})
+	return result, errors.Join(errs...)
+}
@@ -0,0 +1,71 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvVars(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddSource("logs", &KubernetesLogsSource{SelfNodeName: "${VECTOR_SELF_NODE_NAME:?node name is required}"})
+	c.AddSink("loki", &LokiSink{
+		Inputs:   []string{"logs"},
+		Endpoint: "${LOKI_ENDPOINT:-http://loki:3100}",
+		Labels: map[string]string{
+			"vector_instance": "inf-${HOSTNAME}",
+			"host":            "$HOSTNAME",
+			"price":           "$${NOT_A_VAR}",
+		},
+	})
+
+	defaultEndpoint := "http://loki:3100"
+	require.Equal(t, []EnvVar{
+		{Name: "HOSTNAME", AllowEmpty: true},
+		{Name: "LOKI_ENDPOINT", Default: &defaultEndpoint},
+		{Name: "VECTOR_SELF_NODE_NAME", Required: true, Message: "node name is required"},
+	}, c.EnvVars())
+	require.Equal(t, []string{"HOSTNAME", "LOKI_ENDPOINT", "VECTOR_SELF_NODE_NAME"}, c.EnvVarNames())
+
+	got, err := c.Interpolate(map[string]string{
+		"HOSTNAME":              "node-1",
+		"LOKI_ENDPOINT":         "",
+		"VECTOR_SELF_NODE_NAME": "node-1",
+	})
+	require.NoError(t, err)
+	sink, ok := got.GetSink("loki")
+	require.True(t, ok)
+	require.Equal(t, "http://loki:3100", sink["endpoint"])
+	require.Equal(t, map[string]any{
+		"vector_instance": "inf-node-1",
+		"host":            "node-1",
+		"price":           "${NOT_A_VAR}",
+	}, sink["labels"])
+
+	// The original configuration keeps its references.
+	sink, ok = c.GetSink("loki")
+	require.True(t, ok)
+	require.Equal(t, "${LOKI_ENDPOINT:-http://loki:3100}", sink["endpoint"])
+
+	_, err = c.Interpolate(map[string]string{"HOSTNAME": "node-1"})
+	require.ErrorIs(t, err, ErrEnvVarUnset)
+	require.EqualError(t, err, "source 'logs': required environment variable is not set: 'VECTOR_SELF_NODE_NAME': node name is required")
+}
+
+func TestEscapeEnv(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddSource("logs", new(KubernetesLogsSource))
+	c.AddTransform("price", &RemapTransform{Inputs: []string{"logs"}, Source: EscapeEnv(`.price = "$5 ${each}"`)})
+
+	require.Empty(t, c.EnvVars())
+
+	got, err := c.Interpolate(nil)
+	require.NoError(t, err)
+	transform, ok := got.GetTransform("price")
+	require.True(t, ok)
+	require.Equal(t, `.price = "$5 ${each}"`, transform["source"])
+}
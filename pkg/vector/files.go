@@ -0,0 +1,176 @@
+package vector
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+const (
+	// rootFileName is the name, without extension, of the file holding global options and unit tests in a
+	// configuration directory.
+	rootFileName = "vector"
+
+	// vrlExtension is the extension of the VRL programs of remap transforms in a configuration directory.
+	vrlExtension = ".vrl"
+)
+
+// Files renders the configuration in the configuration directory layout loaded by "vector --config-dir", returning
+// the content of each file keyed by its slash separated path. Each component is written to a file named after its key
+// in the directory of its kind, e.g. "sources/kubernetes_logs.yaml". Global options and unit tests are written to
+// "vector.yaml".
+//
+// If dir is not empty, the programs of remap transforms are written to "transforms/<key>.vrl" and referenced through
+// the file option, dir being the directory vector loads the files from.
+func (c *Config) Files(format Format, dir string) (map[string]string, error) {
+	return c.files(format, dir, false)
+}
+
+// FlatFiles renders the configuration as files that all sit in the root of a configuration directory, as a
+// ConfigMap mounted without items is. Each component is written to "<kind>.<key>.<ext>", e.g.
+// "sources.kubernetes_logs.yaml", wrapped in the table of its kind, so vector merges the files like any other root
+// level config file. Global options and unit tests are written to "vector.yaml".
+//
+// If dir is not empty, the programs of remap transforms are written to "transforms.<key>.vrl" and referenced through
+// the file option, dir being the directory vector loads the files from.
+func (c *Config) FlatFiles(format Format, dir string) (map[string]string, error) {
+	return c.files(format, dir, true)
+}
+
+// files renders the configuration in the layout of Files, or of FlatFiles if flat is set.
+func (c *Config) files(format Format, dir string, flat bool) (map[string]string, error) {
+	document, err := c.document()
+	if err != nil {
+		return nil, err
+	}
+
+	ext := "." + format.Extension()
+	files := make(map[string]string)
+	root := make(map[string]any)
+	for _, top := range sortedKeys(document) {
+		if _, ok := documentKinds[top]; !ok {
+			root[top] = document[top]
+			continue
+		}
+
+		components, _ := document[top].(map[string]any)
+		for _, key := range sortedKeys(components) {
+			name := path.Join(top, key)
+			if flat {
+				name = top + "." + key
+			}
+
+			cfg, _ := components[key].(map[string]any)
+			source, ok := cfg["source"].(string)
+			if ok && dir != "" && top == "transforms" && typeOf(cfg) == "remap" {
+				files[name+vrlExtension] = source
+				delete(cfg, "source")
+				cfg["file"] = path.Join(dir, name+vrlExtension)
+			}
+
+			var content any = cfg
+			if flat {
+				content = map[string]any{top: map[string]any{key: cfg}}
+			}
+			rendered, err := renderFile(format, content)
+			if err != nil {
+				return nil, fmt.Errorf("error rendering %s '%s': %w", documentKinds[top], key, err)
+			}
+			files[name+ext] = rendered
+		}
+	}
+
+	if len(root) > 0 {
+		rendered, err := renderFile(format, root)
+		if err != nil {
+			return nil, err
+		}
+		files[rootFileName+ext] = rendered
+	}
+
+	return files, nil
+}
+
+// ParseFiles parses a configuration in the layout rendered by Files or FlatFiles, keyed by slash separated path. The
+// format of each file is taken from its extension. Remap transforms referring to a VRL program of the files have it
+// inlined again.
+func ParseFiles(files map[string]string) (*Config, error) {
+	c := NewConfig()
+	for _, name := range sortedKeys(files) {
+		ext := path.Ext(name)
+		if ext == vrlExtension {
+			continue
+		}
+
+		format, err := ParseFormat(ext)
+		if err != nil {
+			return nil, fmt.Errorf("file '%s': %w", name, err)
+		}
+
+		dir, base := path.Split(name)
+		if dir == "" {
+			parsed, err := Parse(format, []byte(files[name]))
+			if err != nil {
+				return nil, fmt.Errorf("file '%s': %w", name, err)
+			}
+			for _, cfg := range parsed.internal.Transforms {
+				inlineVRL(files, cfg)
+			}
+			if err := c.merge(Fragment{Name: name, Config: parsed}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		kind, ok := documentKinds[strings.TrimSuffix(dir, "/")]
+		if !ok {
+			return nil, fmt.Errorf("file '%s' is not in a component directory", name)
+		}
+
+		raw, err := toJSON(format, []byte(files[name]))
+		if err != nil {
+			return nil, fmt.Errorf("file '%s': %w", name, err)
+		}
+		cfg, err := decodeJSON(raw)
+		if err != nil {
+			return nil, fmt.Errorf("file '%s': %w", name, err)
+		}
+		normalizeNumbers(cfg)
+
+		if kind == KindTransform {
+			inlineVRL(files, cfg)
+		}
+		key := strings.TrimSuffix(base, ext)
+		if err := c.add(kind, key, cfg); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// inlineVRL replaces the file option of a remap transform with the program it refers to, if it is one of files.
+func inlineVRL(files map[string]string, cfg map[string]any) {
+	file, ok := cfg["file"].(string)
+	if !ok || typeOf(cfg) != "remap" {
+		return
+	}
+
+	for _, name := range sortedKeys(files) {
+		if path.Ext(name) != vrlExtension || (file != name && !strings.HasSuffix(file, "/"+name)) {
+			continue
+		}
+		delete(cfg, "file")
+		cfg["source"] = files[name]
+		return
+	}
+}
+
+// renderFile renders a single file of a configuration directory.
+func renderFile(format Format, v any) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("error encoding config: %w", err)
+	}
+	return renderDocument(format, raw)
+}
@@ -0,0 +1,109 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFiles(t *testing.T) {
+	t.Parallel()
+
+	c := testedConfig(t)
+	c.SetGlobal("data_dir", "/var/lib/vector")
+
+	files, err := c.Files(FormatYAML, "/etc/vector")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"sources/in.yaml": "type: kubernetes_logs\n",
+		"transforms/parse.yaml": `file: /etc/vector/transforms/parse.vrl
+inputs:
+- in
+type: remap
+`,
+		"transforms/parse.vrl": ". = parse_json!(.message)",
+		"sinks/out.yaml": `inputs:
+- parse
+type: blackhole
+`,
+		"vector.yaml": `data_dir: /var/lib/vector
+tests:
+- inputs:
+  - insert_at: parse
+    type: raw
+    value: '{"level": "info"}'
+  name: parses json
+  outputs:
+  - conditions:
+    - .level == "info"
+    extract_from:
+    - parse
+`,
+	}, files)
+
+	parsed, err := ParseFiles(files)
+	require.NoError(t, err)
+	want, err := c.Canonical()
+	require.NoError(t, err)
+	got, err := parsed.Canonical()
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+	require.Equal(t, c.Tests(), parsed.Tests())
+}
+
+func TestFlatFiles(t *testing.T) {
+	t.Parallel()
+
+	c := testedConfig(t)
+
+	files, err := c.FlatFiles(FormatYAML, "/etc/vector")
+	require.NoError(t, err)
+	require.Equal(t, `transforms:
+  parse:
+    file: /etc/vector/transforms.parse.vrl
+    inputs:
+    - in
+    type: remap
+`, files["transforms.parse.yaml"])
+	require.Equal(t, ". = parse_json!(.message)", files["transforms.parse.vrl"])
+	require.ElementsMatch(t, []string{
+		"sources.in.yaml",
+		"transforms.parse.yaml",
+		"transforms.parse.vrl",
+		"sinks.out.yaml",
+		"vector.yaml",
+	}, sortedKeys(files))
+
+	parsed, err := ParseFiles(files)
+	require.NoError(t, err)
+	want, err := c.Canonical()
+	require.NoError(t, err)
+	got, err := parsed.Canonical()
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+	require.Equal(t, c.Tests(), parsed.Tests())
+}
+
+func TestFilesInlineVRL(t *testing.T) {
+	t.Parallel()
+
+	c := testedConfig(t)
+
+	files, err := c.Files(FormatTOML, "")
+	require.NoError(t, err)
+	require.NotContains(t, files, "transforms/parse.vrl")
+	require.Equal(t, `inputs = ['in']
+source = '. = parse_json!(.message)'
+type = 'remap'
+`, files["transforms/parse.toml"])
+}
+
+func TestParseFilesErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseFiles(map[string]string{"pipelines/in.yaml": "type: stdin\n"})
+	require.EqualError(t, err, "file 'pipelines/in.yaml' is not in a component directory")
+
+	_, err = ParseFiles(map[string]string{"sources/in.txt": "type: stdin\n"})
+	require.EqualError(t, err, "file 'sources/in.txt': unknown config format '.txt'")
+}
@@ -0,0 +1,135 @@
+package vector
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GlobalOptions are the top-level options of vector.
+//
+// https://vector.dev/docs/reference/configuration/global-options/
+type GlobalOptions struct {
+	Acknowledgements  *Acknowledgements   `json:"acknowledgements,omitempty"`
+	API               *APIOptions         `json:"api,omitempty"`
+	DataDir           string              `json:"data_dir,omitempty"`
+	ExpireMetricsSecs float64             `json:"expire_metrics_secs,omitempty"`
+	Healthchecks      *HealthcheckOptions `json:"healthchecks,omitempty"`
+	LogSchema         *LogSchema          `json:"log_schema,omitempty"`
+	Proxy             *Proxy              `json:"proxy,omitempty"`
+	Schema            *SchemaOptions      `json:"schema,omitempty"`
+	Timezone          string              `json:"timezone,omitempty"`
+}
+
+// APIOptions configures the vector API, which serves the health endpoint.
+type APIOptions struct {
+	Address    string `json:"address,omitempty"`
+	Enabled    bool   `json:"enabled"`
+	GraphQL    *bool  `json:"graphql,omitempty"`
+	Playground *bool  `json:"playground,omitempty"`
+}
+
+// HealthcheckOptions configures the startup health checks of all sinks.
+type HealthcheckOptions struct {
+	Enabled        *bool `json:"enabled,omitempty"`
+	RequireHealthy *bool `json:"require_healthy,omitempty"`
+}
+
+// LogSchema configures the names of the fields vector adds to log events.
+type LogSchema struct {
+	HostKey       string `json:"host_key,omitempty"`
+	MessageKey    string `json:"message_key,omitempty"`
+	MetadataKey   string `json:"metadata_key,omitempty"`
+	SourceTypeKey string `json:"source_type_key,omitempty"`
+	TimestampKey  string `json:"timestamp_key,omitempty"`
+}
+
+// Proxy configures the HTTP proxy used by all components.
+type Proxy struct {
+	Enabled *bool    `json:"enabled,omitempty"`
+	HTTP    string   `json:"http,omitempty"`
+	HTTPS   string   `json:"https,omitempty"`
+	NoProxy []string `json:"no_proxy,omitempty"`
+}
+
+// SchemaOptions configures vector's schema support.
+type SchemaOptions struct {
+	Enabled      *bool `json:"enabled,omitempty"`
+	LogNamespace *bool `json:"log_namespace,omitempty"`
+	Validation   *bool `json:"validation,omitempty"`
+}
+
+// SetGlobalOptions sets the options that are set in opts, leaving any other global options untouched.
+func (c *Config) SetGlobalOptions(opts *GlobalOptions) error {
+	raw, err := json.Marshal(opts)
+	if err != nil {
+		return fmt.Errorf("error encoding global options: %w", err)
+	}
+
+	values := make(map[string]any)
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return fmt.Errorf("error decoding global options: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, value := range values {
+		c.internal.Globals[key] = value
+	}
+	return nil
+}
+
+// GlobalOptions returns the typed global options of the configuration. Options that GlobalOptions does not model
+// are available through Global.
+func (c *Config) GlobalOptions() (*GlobalOptions, error) {
+	c.mu.RLock()
+	raw, err := json.Marshal(c.internal.Globals)
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, fmt.Errorf("error encoding global options: %w", err)
+	}
+
+	result := new(GlobalOptions)
+	if err := json.Unmarshal(raw, result); err != nil {
+		return nil, fmt.Errorf("error decoding global options: %w", err)
+	}
+	return result, nil
+}
+
+// SetGlobal sets the top-level option key to value, replacing any existing value.
+func (c *Config) SetGlobal(key string, value any) error {
+	if _, ok := documentKinds[key]; ok || key == testsKey {
+		return fmt.Errorf("'%s' holds components and cannot be set as a global option", key)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.internal.Globals[key] = copyValue(value)
+	return nil
+}
+
+// Global returns a copy of the top-level option key.
+func (c *Config) Global(key string) (any, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	value, ok := c.internal.Globals[key]
+	if !ok {
+		return nil, false
+	}
+	return copyValue(value), true
+}
+
+// RemoveGlobal removes the top-level option key, returning ErrNotFound if it is not set.
+func (c *Config) RemoveGlobal(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.internal.Globals[key]; !ok {
+		return fmt.Errorf("%w: %s key '%s'", ErrNotFound, KindGlobal, key)
+	}
+
+	delete(c.internal.Globals, key)
+	return nil
+}
@@ -0,0 +1,51 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalOptions(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddSource("logs", new(KubernetesLogsSource))
+	c.AddSink("out", &BlackholeSink{Inputs: []string{"logs"}})
+
+	require.NoError(t, c.SetGlobal("enterprise", map[string]any{"enabled": false}))
+	require.NoError(t, c.SetGlobalOptions(&GlobalOptions{
+		DataDir: "/var/lib/vector",
+		API: &APIOptions{
+			Enabled: true,
+			Address: "0.0.0.0:8686",
+		},
+		ExpireMetricsSecs: 300,
+	}))
+
+	got, err := c.JSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"data_dir": "/var/lib/vector",
+		"api": {"enabled": true, "address": "0.0.0.0:8686"},
+		"expire_metrics_secs": 300,
+		"enterprise": {"enabled": false},
+		"sources": {"logs": {"type": "kubernetes_logs"}},
+		"sinks": {"out": {"type": "blackhole", "inputs": ["logs"]}}
+	}`, got)
+
+	opts, err := c.GlobalOptions()
+	require.NoError(t, err)
+	require.Equal(t, "/var/lib/vector", opts.DataDir)
+	require.True(t, opts.API.Enabled)
+
+	// Setting options again only touches the options that are set.
+	require.NoError(t, c.SetGlobalOptions(&GlobalOptions{Timezone: "UTC"}))
+	dataDir, ok := c.Global("data_dir")
+	require.True(t, ok)
+	require.Equal(t, "/var/lib/vector", dataDir)
+
+	require.NoError(t, c.RemoveGlobal("data_dir"))
+	require.ErrorIs(t, c.RemoveGlobal("data_dir"), ErrNotFound)
+	require.EqualError(t, c.SetGlobal("sinks", nil), "'sinks' holds components and cannot be set as a global option")
+}
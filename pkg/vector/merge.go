@@ -0,0 +1,213 @@
+package vector
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ConflictPolicy decides what happens when a fragment defines a component that is already defined.
+type ConflictPolicy string
+
+// Conflict policies supported by Merge.
+const (
+	// ConflictError fails the merge. This is the default.
+	ConflictError ConflictPolicy = "error"
+
+	// ConflictLastWins replaces the existing component with the one from the fragment.
+	ConflictLastWins ConflictPolicy = "last-wins"
+
+	// ConflictDeepMerge merges the fields of the fragment's component into the existing component. Tables are
+	// merged recursively, inputs are appended and any other value is replaced.
+	ConflictDeepMerge ConflictPolicy = "deep-merge"
+)
+
+// Fragment is a named part of a configuration, such as a platform base, team additions or environment overrides.
+type Fragment struct {
+	// Name identifies the fragment in errors and in the provenance of the merged config.
+	Name string
+
+	// Config holds the components of the fragment.
+	Config *Config
+
+	// Policy decides what happens when the fragment defines a component that an earlier fragment already defined.
+	Policy ConflictPolicy
+}
+
+// Merge composes a configuration from a base fragment and overlays, applied in order. Neither the base nor the
+// overlays are modified.
+//
+// The merged configuration records which fragments supplied each component, see Config.Fragments. It targets the
+// vector version of the last fragment that sets one.
+func Merge(base Fragment, overlays ...Fragment) (*Config, error) {
+	result := NewConfig()
+	for _, fragment := range append([]Fragment{base}, overlays...) {
+		if fragment.Config == nil {
+			continue
+		}
+		if err := result.merge(fragment); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Fragments returns the names of the fragments that supplied the component, in the order they were applied. It
+// returns nil if the configuration was not built by Merge.
+func (c *Config) Fragments(kind ComponentKind, key string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return slices.Clone(c.fragments[componentID{kind: kind, key: key}])
+}
+
+// merge applies a fragment to the configuration.
+func (c *Config) merge(fragment Fragment) error {
+	policy := fragment.Policy
+	switch policy {
+	case "":
+		policy = ConflictError
+	case ConflictError, ConflictLastWins, ConflictDeepMerge:
+	default:
+		return fmt.Errorf("unknown conflict policy '%s' for fragment '%s'", policy, fragment.Name)
+	}
+
+	overlay := fragment.Config.Clone()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if overlay.targetVersion != "" {
+		c.targetVersion = overlay.targetVersion
+	}
+
+	for _, kind := range componentKinds {
+		existing := c.components(kind)
+		for _, key := range sortedKeys(overlay.components(kind)) {
+			cfg := overlay.components(kind)[key]
+			if current, ok := existing[key]; ok {
+				if policy == ConflictError {
+					return conflictError(fragment.Name, kind, key)
+				}
+				if policy == ConflictDeepMerge {
+					cfg = mergeTables(current, cfg)
+				}
+			}
+			existing[key] = cfg
+			c.recordFragment(policy, kind, key, fragment.Name)
+			c.mergeMetadata(policy, componentID{kind: kind, key: key}, overlay.metadata)
+		}
+	}
+
+	for _, test := range overlay.internal.Tests {
+		if i := c.testIndex(test.Name); i >= 0 {
+			if policy == ConflictError {
+				return conflictError(fragment.Name, KindTest, test.Name)
+			}
+			c.internal.Tests[i] = test
+		} else {
+			c.internal.Tests = append(c.internal.Tests, test)
+		}
+		c.recordFragment(ConflictLastWins, KindTest, test.Name, fragment.Name)
+	}
+
+	for _, key := range sortedKeys(overlay.internal.Globals) {
+		value := overlay.internal.Globals[key]
+		if current, ok := c.internal.Globals[key]; ok {
+			if policy == ConflictError {
+				return conflictError(fragment.Name, KindGlobal, key)
+			}
+			if policy == ConflictDeepMerge {
+				value = deepMerge(key, current, value)
+			}
+		}
+		c.internal.Globals[key] = value
+		c.recordFragment(policy, KindGlobal, key, fragment.Name)
+	}
+
+	return nil
+}
+
+// conflictError returns the error for a fragment redefining a component under ConflictError.
+func conflictError(fragment string, kind ComponentKind, key string) error {
+	return fmt.Errorf("%w: %s '%s' from fragment '%s' is already defined", ErrDuplicateKey, kind, key, fragment)
+}
+
+// mergeMetadata applies the metadata of a component from a fragment following the policy: last-wins replaces the
+// existing metadata, clearing it if the fragment has none, and deep-merge keeps the existing fields the fragment
+// leaves empty. The caller must hold c.mu.
+func (c *Config) mergeMetadata(policy ConflictPolicy, id componentID, overlay map[componentID]Metadata) {
+	md, ok := overlay[id]
+	current, exists := c.metadata[id]
+	switch {
+	case policy == ConflictDeepMerge && exists:
+		if ok {
+			c.metadata[id] = Metadata{
+				Origin:      cmp.Or(md.Origin, current.Origin),
+				Team:        cmp.Or(md.Team, current.Team),
+				Description: cmp.Or(md.Description, current.Description),
+			}
+		}
+	case ok:
+		c.metadata[id] = md
+	default:
+		delete(c.metadata, id)
+	}
+}
+
+// recordFragment records that the named fragment supplied a component. The caller must hold c.mu.
+func (c *Config) recordFragment(policy ConflictPolicy, kind ComponentKind, key, name string) {
+	id := componentID{kind: kind, key: key}
+	if policy == ConflictDeepMerge {
+		c.fragments[id] = append(c.fragments[id], name)
+		return
+	}
+	c.fragments[id] = []string{name}
+}
+
+// mergeTables merges the overlay table into the base table.
+func mergeTables(base, overlay map[string]any) map[string]any {
+	result := maps.Clone(base)
+	for k, v := range overlay {
+		if existing, ok := result[k]; ok {
+			v = deepMerge(k, existing, v)
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// deepMerge merges overlay into base. The key is the name of the option holding the values.
+func deepMerge(key string, base, overlay any) any {
+	if key == "inputs" {
+		return appendInputs(base, overlay)
+	}
+
+	baseMap, baseIsMap := base.(map[string]any)
+	overlayMap, overlayIsMap := overlay.(map[string]any)
+	if baseIsMap && overlayIsMap {
+		return mergeTables(baseMap, overlayMap)
+	}
+	return overlay
+}
+
+// appendInputs appends the overlay inputs to the base inputs, skipping inputs that are already present.
+func appendInputs(base, overlay any) any {
+	result := slices.Clone(componentInputs(map[string]any{"inputs": base}))
+	for _, input := range componentInputs(map[string]any{"inputs": overlay}) {
+		if !slices.Contains(result, input) {
+			result = append(result, input)
+		}
+	}
+	return result
+}
+
+// copyFragments returns a deep copy of the fragment record of a configuration.
+func copyFragments(fragments map[componentID][]string) map[componentID][]string {
+	result := make(map[componentID][]string, len(fragments))
+	for id, names := range fragments {
+		result[id] = slices.Clone(names)
+	}
+	return result
+}
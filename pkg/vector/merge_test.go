@@ -0,0 +1,94 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mergeTestBase() *Config {
+	c := NewConfig()
+	c.AddSource("logs", new(KubernetesLogsSource))
+	c.AddSink("loki", &LokiSink{
+		Inputs:   []string{"logs"},
+		Endpoint: "http://loki:3100",
+		Encoding: Encoding{Codec: "json"},
+		Labels:   map[string]string{"source": "vector"},
+	})
+	return c
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	team := NewConfig()
+	team.AddSource("team_logs", &FileSource{Include: []string{"/var/log/team/*.log"}})
+	team.AddSinkUntyped("loki", map[string]any{
+		"inputs": []string{"team_logs", "logs"},
+		"labels": map[string]any{"team": "a"},
+	})
+
+	env := NewConfig()
+	env.AddSinkUntyped("loki", map[string]any{
+		"type":     "loki",
+		"inputs":   []string{"logs", "team_logs"},
+		"endpoint": "http://loki.staging:3100",
+		"encoding": map[string]any{"codec": "json"},
+	})
+
+	merged, err := Merge(
+		Fragment{Name: "platform", Config: mergeTestBase()},
+		Fragment{Name: "team-a", Config: team, Policy: ConflictDeepMerge},
+	)
+	require.NoError(t, err)
+	require.NoError(t, merged.Validate())
+
+	got, err := merged.JSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"sources": {
+			"logs": {"type": "kubernetes_logs"},
+			"team_logs": {"type": "file", "include": ["/var/log/team/*.log"]}
+		},
+		"sinks": {
+			"loki": {
+				"type": "loki",
+				"inputs": ["logs", "team_logs"],
+				"endpoint": "http://loki:3100",
+				"encoding": {"codec": "json"},
+				"labels": {"source": "vector", "team": "a"}
+			}
+		}
+	}`, got)
+	require.Equal(t, []string{"platform", "team-a"}, merged.Fragments(KindSink, "loki"))
+	require.Equal(t, []string{"team-a"}, merged.Fragments(KindSource, "team_logs"))
+	require.Nil(t, merged.Fragments(KindTransform, "nope"))
+
+	overridden, err := Merge(
+		Fragment{Name: "merged", Config: merged},
+		Fragment{Name: "staging", Config: env, Policy: ConflictLastWins},
+	)
+	require.NoError(t, err)
+	sink, ok := overridden.GetSink("loki")
+	require.True(t, ok)
+	require.Equal(t, "http://loki.staging:3100", sink["endpoint"])
+	require.NotContains(t, sink, "labels")
+	require.Equal(t, []string{"staging"}, overridden.Fragments(KindSink, "loki"))
+}
+
+func TestMergeConflict(t *testing.T) {
+	t.Parallel()
+
+	_, err := Merge(
+		Fragment{Name: "platform", Config: mergeTestBase()},
+		Fragment{Name: "team-a", Config: mergeTestBase()},
+	)
+	require.ErrorIs(t, err, ErrDuplicateKey)
+	require.EqualError(t, err, "duplicate component key: source 'logs' from fragment 'team-a' is already defined")
+
+	_, err = Merge(
+		Fragment{Name: "platform", Config: mergeTestBase()},
+		Fragment{Name: "team-a", Config: mergeTestBase(), Policy: "first-wins"},
+	)
+	require.EqualError(t, err, "unknown conflict policy 'first-wins' for fragment 'team-a'")
+}
@@ -0,0 +1,110 @@
+package vector
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Acknowledgements configures end-to-end acknowledgements for a sink.
+type Acknowledgements struct {
+	Enabled bool `json:"enabled"`
+}
+
+// Batch configures how a sink batches events before sending them.
+type Batch struct {
+	MaxBytes    int     `json:"max_bytes,omitempty"`
+	MaxEvents   int     `json:"max_events,omitempty"`
+	TimeoutSecs float64 `json:"timeout_secs,omitempty"`
+}
+
+// Buffer types supported by vector sinks.
+const (
+	BufferTypeMemory = "memory"
+	BufferTypeDisk   = "disk"
+)
+
+// Buffer configures the buffer in front of a sink.
+type Buffer struct {
+	MaxEvents int    `json:"max_events,omitempty"`
+	MaxSize   int    `json:"max_size,omitempty"`
+	Type      string `json:"type,omitempty"`
+	WhenFull  string `json:"when_full,omitempty"`
+}
+
+// Concurrency is the request concurrency of a sink.
+type Concurrency string
+
+// Concurrency modes supported by vector sinks. Fixed limits are created with FixedConcurrency.
+const (
+	ConcurrencyAdaptive Concurrency = "adaptive"
+	ConcurrencyNone     Concurrency = "none"
+)
+
+// FixedConcurrency returns a concurrency limited to n requests in flight.
+func FixedConcurrency(n int) Concurrency {
+	return Concurrency(strconv.Itoa(n))
+}
+
+// MarshalJSON implements json.Marshaler, rendering fixed limits as numbers.
+func (c Concurrency) MarshalJSON() ([]byte, error) {
+	if n, err := strconv.Atoi(string(c)); err == nil {
+		return json.Marshal(n)
+	}
+	return json.Marshal(string(c))
+}
+
+// Request configures the requests a sink makes to its destination.
+type Request struct {
+	Concurrency             Concurrency       `json:"concurrency,omitempty"`
+	Headers                 map[string]string `json:"headers,omitempty"`
+	RateLimitDurationSecs   int               `json:"rate_limit_duration_secs,omitempty"`
+	RateLimitNum            int               `json:"rate_limit_num,omitempty"`
+	RetryAttempts           int               `json:"retry_attempts,omitempty"`
+	RetryInitialBackoffSecs int               `json:"retry_initial_backoff_secs,omitempty"`
+	RetryMaxDurationSecs    int               `json:"retry_max_duration_secs,omitempty"`
+	TimeoutSecs             int               `json:"timeout_secs,omitempty"`
+}
+
+// Encoding configures how a sink encodes events.
+type Encoding struct {
+	Codec           string   `json:"codec"`
+	ExceptFields    []string `json:"except_fields,omitempty"`
+	OnlyFields      []string `json:"only_fields,omitempty"`
+	TimestampFormat string   `json:"timestamp_format,omitempty"`
+}
+
+// TLS configures the TLS options of a component.
+type TLS struct {
+	ALPNProtocols     []string `json:"alpn_protocols,omitempty"`
+	CAFile            string   `json:"ca_file,omitempty"`
+	CRTFile           string   `json:"crt_file,omitempty"`
+	Enabled           *bool    `json:"enabled,omitempty"`
+	KeyFile           string   `json:"key_file,omitempty"`
+	KeyPass           string   `json:"key_pass,omitempty"`
+	ServerName        string   `json:"server_name,omitempty"`
+	VerifyCertificate *bool    `json:"verify_certificate,omitempty"`
+	VerifyHostname    *bool    `json:"verify_hostname,omitempty"`
+}
+
+// Healthcheck configures the startup health check of a sink.
+type Healthcheck struct {
+	Enabled bool `json:"enabled"`
+}
+
+// HTTPAuth configures HTTP authentication.
+type HTTPAuth struct {
+	Password string `json:"password,omitempty"`
+	Strategy string `json:"strategy,omitempty"`
+	Token    string `json:"token,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// Decoding configures how incoming bytes are decoded into events.
+type Decoding struct {
+	Codec string `json:"codec"`
+}
+
+// Framing configures how incoming bytes are split into frames.
+type Framing struct {
+	Method string `json:"method"`
+}
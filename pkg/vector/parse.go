@@ -0,0 +1,167 @@
+package vector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// ParseJSON parses a vector configuration in JSON format.
+func ParseJSON(data []byte) (*Config, error) {
+	return Parse(FormatJSON, data)
+}
+
+// ParseYAML parses a vector configuration in YAML format.
+func ParseYAML(data []byte) (*Config, error) {
+	return Parse(FormatYAML, data)
+}
+
+// ParseTOML parses a vector configuration in TOML format.
+func ParseTOML(data []byte) (*Config, error) {
+	return Parse(FormatTOML, data)
+}
+
+// Parse parses a vector configuration in the given format.
+func Parse(format Format, data []byte) (*Config, error) {
+	raw, err := toJSON(format, data)
+	if err != nil {
+		return nil, err
+	}
+	return parseDocument(raw)
+}
+
+// toJSON converts a document in the given format to JSON.
+func toJSON(format Format, data []byte) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return data, nil
+	case FormatYAML:
+		raw, err := yaml.YAMLToJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding yaml config: %w", err)
+		}
+		return raw, nil
+	case FormatTOML:
+		document := make(map[string]any)
+		if err := toml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("error decoding toml config: %w", err)
+		}
+
+		raw, err := json.Marshal(document)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding toml config: %w", err)
+		}
+		return raw, nil
+	default:
+		return nil, fmt.Errorf("unknown config format '%s'", format)
+	}
+}
+
+// DetectFormat guesses the format of a vector configuration from its content.
+func DetectFormat(data []byte) Format {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		return FormatJSON
+	}
+
+	if err := toml.Unmarshal(trimmed, new(map[string]any)); err == nil {
+		return FormatTOML
+	}
+
+	return FormatYAML
+}
+
+// Load parses a vector configuration, detecting its format from the content.
+func Load(data []byte) (*Config, error) {
+	return Parse(DetectFormat(data), data)
+}
+
+// LoadFile parses the vector configuration at path. The format is taken from the file extension where possible
+// and detected from the content otherwise.
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	format, err := ParseFormat(filepath.Ext(path))
+	if err != nil {
+		return Load(data)
+	}
+	return Parse(format, data)
+}
+
+// parseDocument fills a configuration from a JSON document.
+func parseDocument(raw []byte) (*Config, error) {
+	document, err := decodeJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewConfig()
+	for key, value := range document {
+		value = normalizeNumbers(value)
+
+		if key == testsKey {
+			tests, err := parseTests(value)
+			if err != nil {
+				return nil, err
+			}
+			c.internal.Tests = tests
+			continue
+		}
+
+		kind, ok := documentKinds[key]
+		if !ok {
+			c.internal.Globals[key] = value
+			continue
+		}
+
+		components, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("'%s' must be a table of components", key)
+		}
+		for componentKey, cfg := range components {
+			component, ok := cfg.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%s '%s' must be a table", kind, componentKey)
+			}
+			if err := c.add(kind, componentKey, component); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// normalizeNumbers replaces the json.Number values produced by decodeJSON with int64 or float64 values.
+func normalizeNumbers(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			v[k] = normalizeNumbers(item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeNumbers(item)
+		}
+		return v
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	default:
+		return v
+	}
+}
@@ -0,0 +1,138 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const parseTestJSON = `{
+	"data_dir": "/var/lib/vector",
+	"api": {"enabled": true, "address": "0.0.0.0:8686"},
+	"secret": {
+		"vault": {"type": "exec", "command": ["/bin/vault-secrets"]}
+	},
+	"sources": {
+		"logs": {"type": "kubernetes_logs", "max_line_bytes": 32768}
+	},
+	"transforms": {
+		"parse": {"type": "remap", "inputs": ["logs"], "source": ". = parse_json!(.message)"}
+	},
+	"sinks": {
+		"out": {
+			"type": "loki",
+			"inputs": ["parse"],
+			"endpoint": "http://loki:3100",
+			"batch": {"timeout_secs": 0.5},
+			"auth": {"strategy": "basic", "password": "SECRET[vault.loki]"}
+		}
+	}
+}`
+
+const parseTestYAML = `
+data_dir: /var/lib/vector
+api:
+  enabled: true
+  address: 0.0.0.0:8686
+secret:
+  vault:
+    type: exec
+    command: [/bin/vault-secrets]
+sources:
+  logs:
+    type: kubernetes_logs
+    max_line_bytes: 32768
+transforms:
+  parse:
+    type: remap
+    inputs: [logs]
+    source: . = parse_json!(.message)
+sinks:
+  out:
+    type: loki
+    inputs: [parse]
+    endpoint: http://loki:3100
+    batch:
+      timeout_secs: 0.5
+    auth:
+      strategy: basic
+      password: SECRET[vault.loki]
+`
+
+const parseTestTOML = `
+data_dir = "/var/lib/vector"
+
+[api]
+enabled = true
+address = "0.0.0.0:8686"
+
+[secret.vault]
+type = "exec"
+command = ["/bin/vault-secrets"]
+
+[sources.logs]
+type = "kubernetes_logs"
+max_line_bytes = 32768
+
+[transforms.parse]
+type = "remap"
+inputs = ["logs"]
+source = ". = parse_json!(.message)"
+
+[sinks.out]
+type = "loki"
+inputs = ["parse"]
+endpoint = "http://loki:3100"
+batch.timeout_secs = 0.5
+auth.strategy = "basic"
+auth.password = "SECRET[vault.loki]"
+`
+
+func TestParseRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format Format
+		data   string
+	}{
+		{name: "json", format: FormatJSON, data: parseTestJSON},
+		{name: "yaml", format: FormatYAML, data: parseTestYAML},
+		{name: "toml", format: FormatTOML, data: parseTestTOML},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.format, DetectFormat([]byte(tt.data)))
+
+			c, err := Load([]byte(tt.data))
+			require.NoError(t, err)
+			require.NoError(t, c.Validate())
+
+			got, err := c.JSON()
+			require.NoError(t, err)
+			require.JSONEq(t, parseTestJSON, got)
+
+			// Rendering in the original format and parsing it again must not lose anything.
+			rendered, err := c.Render(tt.format)
+			require.NoError(t, err)
+			reparsed, err := Parse(tt.format, []byte(rendered))
+			require.NoError(t, err)
+			got, err = reparsed.JSON()
+			require.NoError(t, err)
+			require.JSONEq(t, parseTestJSON, got)
+		})
+	}
+}
+
+func TestParseInvalidComponents(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseJSON([]byte(`{"sources": ["logs"]}`))
+	require.EqualError(t, err, "'sources' must be a table of components")
+
+	_, err = ParseJSON([]byte(`{"sinks": {"out": "loki"}}`))
+	require.EqualError(t, err, "sink 'out' must be a table")
+}
@@ -0,0 +1,191 @@
+package vector
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PipelineBuilder builds a chain of components, filling in their inputs. Create one with Pipeline.
+//
+// Each component consumes the outputs of the components before it:
+//
+//	vector.Pipeline("logs").
+//		From("kubernetes_logs", new(vector.KubernetesLogsSource)).
+//		Through(vector.Step("parse", parse), vector.Step("sample", sample)).
+//		To("loki", loki).
+//		To("archive", archive)
+//
+// Calling From several times fans in, calling To several times fans out. Branch continues from a named output of
+// the last transform, such as a route.
+type PipelineBuilder struct {
+	state *pipelineState
+
+	// outputs are the outputs the next component consumes.
+	outputs []string
+}
+
+// pipelineState is shared by a pipeline and its branches.
+type pipelineState struct {
+	name     string
+	steps    []pipelineStep
+	errs     []error
+	metadata *Metadata
+}
+
+// pipelineStep is a component added by a pipeline.
+type pipelineStep struct {
+	kind ComponentKind
+	key  string
+	cfg  map[string]any
+}
+
+// TransformStep is a transform of a pipeline and its key.
+type TransformStep struct {
+	Key       string
+	Transform Transform
+}
+
+// Step pairs a transform with its key for PipelineBuilder.Through.
+func Step(key string, transform Transform) TransformStep {
+	return TransformStep{Key: key, Transform: transform}
+}
+
+// Pipeline starts a new pipeline. The name identifies the pipeline in errors.
+func Pipeline(name string) *PipelineBuilder {
+	return &PipelineBuilder{
+		state: &pipelineState{
+			name:  name,
+			steps: make([]pipelineStep, 0),
+			errs:  make([]error, 0),
+		},
+		outputs: make([]string, 0),
+	}
+}
+
+// From adds a source under key whose output is consumed by the next component.
+func (p *PipelineBuilder) From(key string, src Source) *PipelineBuilder {
+	cfg, err := componentMap(src.SourceType(), src)
+	if err != nil {
+		return p.fail(err)
+	}
+
+	p.state.steps = append(p.state.steps, pipelineStep{kind: KindSource, key: key, cfg: cfg})
+	p.outputs = append(p.outputs, key)
+	return p
+}
+
+// FromExisting makes the next component consume components that are not part of the pipeline, e.g. a source shared
+// by several pipelines. The inputs may use any form Validate accepts.
+func (p *PipelineBuilder) FromExisting(inputs ...string) *PipelineBuilder {
+	p.outputs = append(p.outputs, inputs...)
+	return p
+}
+
+// Through adds transforms, each consuming the output of the one before it. The first consumes the current outputs.
+func (p *PipelineBuilder) Through(steps ...TransformStep) *PipelineBuilder {
+	for _, step := range steps {
+		p.through(step.Key, step.Transform)
+	}
+	return p
+}
+
+// To adds a sink under key consuming the current outputs. The outputs are left as they are, so further sinks or
+// transforms can consume them too.
+func (p *PipelineBuilder) To(key string, sink Sink) *PipelineBuilder {
+	cfg, err := componentMap(sink.SinkType(), sink)
+	if err != nil {
+		return p.fail(err)
+	}
+	return p.add(KindSink, key, cfg)
+}
+
+// Branch returns a pipeline continuing from the named output of the last transform, e.g. a route of a route
+// transform or "_unmatched". The branch shares the components of the pipeline, and is built with it.
+func (p *PipelineBuilder) Branch(output string) *PipelineBuilder {
+	branch := &PipelineBuilder{
+		state:   p.state,
+		outputs: make([]string, 0, 1),
+	}
+
+	if len(p.outputs) != 1 {
+		return branch.fail(fmt.Errorf("branch '%s' must follow a single transform", output))
+	}
+
+	branch.outputs = append(branch.outputs, p.outputs[0]+"."+output)
+	return branch
+}
+
+// WithMetadata sets the metadata of every component of the pipeline and its branches.
+func (p *PipelineBuilder) WithMetadata(md Metadata) *PipelineBuilder {
+	p.state.metadata = &md
+	return p
+}
+
+// Build adds the components of the pipeline and its branches to c. It returns every problem found, in which case
+// none of the components are added.
+func (p *PipelineBuilder) Build(c *Config) error {
+	if err := errors.Join(p.state.errs...); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	errs := make([]error, 0)
+	added := make(map[componentID]bool, len(p.state.steps))
+	for _, step := range p.state.steps {
+		id := componentID{kind: step.kind, key: step.key}
+		if _, ok := c.components(step.kind)[step.key]; ok || added[id] {
+			errs = append(errs, fmt.Errorf("pipeline '%s': %w: %s key '%s' already added to configuration",
+				p.state.name, ErrDuplicateKey, step.kind, step.key))
+		}
+		added[id] = true
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	for _, step := range p.state.steps {
+		c.components(step.kind)[step.key] = copyComponent(step.cfg)
+		if p.state.metadata != nil {
+			c.metadata[componentID{kind: step.kind, key: step.key}] = *p.state.metadata
+		}
+	}
+	return nil
+}
+
+// through adds a transform consuming the current outputs, which becomes the only output.
+func (p *PipelineBuilder) through(key string, transform Transform) {
+	cfg, err := componentMap(transform.TransformType(), transform)
+	if err != nil {
+		p.fail(err)
+		return
+	}
+
+	p.add(KindTransform, key, cfg)
+	p.outputs = []string{key}
+}
+
+// add records a component consuming the current outputs.
+func (p *PipelineBuilder) add(kind ComponentKind, key string, cfg map[string]any) *PipelineBuilder {
+	if len(p.outputs) == 0 {
+		return p.fail(fmt.Errorf("%w: %s '%s' has nothing to consume", ErrNoInputs, kind, key))
+	}
+
+	inputs := make([]string, 0, len(p.outputs))
+	inputs = append(inputs, p.outputs...)
+	if existing, ok := cfg["inputs"]; ok {
+		cfg["inputs"] = appendInputs(existing, inputs)
+	} else {
+		cfg["inputs"] = inputs
+	}
+
+	p.state.steps = append(p.state.steps, pipelineStep{kind: kind, key: key, cfg: cfg})
+	return p
+}
+
+// fail records a problem, reported by Build.
+func (p *PipelineBuilder) fail(err error) *PipelineBuilder {
+	p.state.errs = append(p.state.errs, fmt.Errorf("pipeline '%s': %w", p.state.name, err))
+	return p
+}
@@ -0,0 +1,76 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddSource("internal_metrics", new(InternalMetricsSource))
+
+	logs := Pipeline("logs").
+		From("kubernetes_logs", new(KubernetesLogsSource)).
+		From("journald", new(JournaldSource)).
+		Through(
+			Step("parse", &RemapTransform{Source: ".message = parse_json!(.message)"}),
+			Step("route", &RouteTransform{Route: map[string]Condition{"errors": VRLCondition(`.level == "error"`)}}),
+		)
+	logs.Branch("errors").
+		To("alerts", &HTTPSink{URI: "http://alertmanager", Encoding: Encoding{Codec: "json"}})
+	logs.Branch("_unmatched").
+		Through(Step("sample", &SampleTransform{Rate: 10})).
+		To("loki", &LokiSink{Endpoint: "http://loki:3100", Encoding: Encoding{Codec: "json"}}).
+		To("archive", &BlackholeSink{})
+	require.NoError(t, logs.Build(c))
+
+	require.NoError(t, Pipeline("metrics").
+		FromExisting("internal_metrics").
+		To("prometheus", &PrometheusExporterSink{Address: "0.0.0.0:9090"}).
+		Build(c))
+
+	require.NoError(t, c.Validate())
+
+	inputs := func(kind ComponentKind, key string) []string {
+		cfg, ok := c.get(kind, key)
+		require.True(t, ok, key)
+		return componentInputs(cfg)
+	}
+	require.Equal(t, []string{"kubernetes_logs", "journald"}, inputs(KindTransform, "parse"))
+	require.Equal(t, []string{"parse"}, inputs(KindTransform, "route"))
+	require.Equal(t, []string{"route.errors"}, inputs(KindSink, "alerts"))
+	require.Equal(t, []string{"route._unmatched"}, inputs(KindTransform, "sample"))
+	require.Equal(t, []string{"sample"}, inputs(KindSink, "loki"))
+	require.Equal(t, []string{"sample"}, inputs(KindSink, "archive"))
+	require.Equal(t, []string{"internal_metrics"}, inputs(KindSink, "prometheus"))
+}
+
+func TestPipelineErrors(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddSource("logs", new(KubernetesLogsSource))
+
+	err := Pipeline("logs").
+		From("logs", new(KubernetesLogsSource)).
+		To("out", new(BlackholeSink)).
+		Build(c)
+	require.ErrorIs(t, err, ErrDuplicateKey)
+	_, ok := c.GetSink("out")
+	require.False(t, ok, "no component is added when the pipeline fails")
+
+	err = Pipeline("empty").To("out", new(BlackholeSink)).Build(c)
+	require.ErrorIs(t, err, ErrNoInputs)
+
+	err = Pipeline("fan-in").
+		From("a", new(KubernetesLogsSource)).
+		From("b", new(KubernetesLogsSource)).
+		Branch("errors").
+		To("out", new(BlackholeSink)).
+		Build(c)
+	require.EqualError(t, err, "pipeline 'fan-in': branch 'errors' must follow a single transform\n"+
+		"pipeline 'fan-in': component has no inputs: sink 'out' has nothing to consume")
+}
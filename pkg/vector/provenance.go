@@ -0,0 +1,109 @@
+package vector
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Metadata describes where a component comes from. It is not part of the rendered configuration.
+type Metadata struct {
+	// Origin is what added the component, e.g. a function, ConfigMap or custom resource.
+	Origin string `json:"origin,omitempty"`
+
+	// Team is the team owning the component.
+	Team string `json:"team,omitempty"`
+
+	// Description says what the component is for.
+	Description string `json:"description,omitempty"`
+}
+
+// Provenance is the metadata of a component together with the component it describes.
+type Provenance struct {
+	Kind ComponentKind `json:"kind"`
+	Key  string        `json:"key"`
+	Type string        `json:"type"`
+
+	Metadata
+
+	// Fragments are the names of the fragments that supplied the component, see Config.Fragments.
+	Fragments []string `json:"fragments,omitempty"`
+}
+
+// SetMetadata sets the metadata of the component, returning ErrNotFound if there is none.
+func (c *Config) SetMetadata(kind ComponentKind, key string, md Metadata) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.components(kind)[key]; !ok {
+		return fmt.Errorf("%w: %s key '%s'", ErrNotFound, kind, key)
+	}
+
+	c.metadata[componentID{kind: kind, key: key}] = md
+	return nil
+}
+
+// Metadata returns the metadata of the component, if any was set.
+func (c *Config) Metadata(kind ComponentKind, key string) (Metadata, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	md, ok := c.metadata[componentID{kind: kind, key: key}]
+	return md, ok
+}
+
+// ComponentsBy returns the provenance of the components added by origin.
+func (c *Config) ComponentsBy(origin string) []Provenance {
+	return c.provenanceMatching(func(p Provenance) bool {
+		return p.Origin == origin
+	})
+}
+
+// ComponentsOwnedBy returns the provenance of the components owned by team.
+func (c *Config) ComponentsOwnedBy(team string) []Provenance {
+	return c.provenanceMatching(func(p Provenance) bool {
+		return p.Team == team
+	})
+}
+
+// Provenance returns the provenance of every component, ordered by kind then key. Components without metadata are
+// included, so nothing in the rendered configuration goes unaccounted for.
+func (c *Config) Provenance() []Provenance {
+	return c.provenanceMatching(func(Provenance) bool {
+		return true
+	})
+}
+
+// ProvenanceReport renders the provenance of every component as JSON.
+func (c *Config) ProvenanceReport() (string, error) {
+	raw, err := json.MarshalIndent(c.Provenance(), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error encoding provenance: %w", err)
+	}
+	return string(raw) + "\n", nil
+}
+
+// provenanceMatching returns the provenance of the components matching fn.
+func (c *Config) provenanceMatching(fn func(Provenance) bool) []Provenance {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]Provenance, 0)
+	for _, kind := range componentKinds {
+		components := c.components(kind)
+		for _, key := range sortedKeys(components) {
+			id := componentID{kind: kind, key: key}
+			p := Provenance{
+				Kind:      kind,
+				Key:       key,
+				Type:      typeOf(components[key]),
+				Metadata:  c.metadata[id],
+				Fragments: slices.Clone(c.fragments[id]),
+			}
+			if fn(p) {
+				result = append(result, p)
+			}
+		}
+	}
+	return result
+}
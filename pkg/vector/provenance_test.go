@@ -0,0 +1,91 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvenance(t *testing.T) {
+	t.Parallel()
+
+	platform := NewConfig()
+	require.NoError(t, Pipeline("logs").
+		From("kubernetes_logs", new(KubernetesLogsSource)).
+		To("loki", &LokiSink{Endpoint: "http://loki:3100", Encoding: Encoding{Codec: "json"}}).
+		WithMetadata(Metadata{Origin: "configForLogs", Team: "platform"}).
+		Build(platform))
+
+	team := NewConfig()
+	team.AddSinkUntyped("audit", map[string]any{"type": "blackhole", "inputs": []string{"kubernetes_logs"}})
+	require.NoError(t, team.SetMetadata(KindSink, "audit", Metadata{
+		Origin:      "configmap/payments/vector-audit",
+		Team:        "payments",
+		Description: "Keeps audit logs",
+	}))
+	require.ErrorIs(t, team.SetMetadata(KindSink, "missing", Metadata{}), ErrNotFound)
+
+	c, err := Merge(Fragment{Name: "platform", Config: platform}, Fragment{Name: "payments", Config: team})
+	require.NoError(t, err)
+	c.AddSourceUntyped("internal_metrics", map[string]any{"type": "internal_metrics"})
+
+	md, ok := c.Metadata(KindSink, "audit")
+	require.True(t, ok)
+	require.Equal(t, "payments", md.Team)
+
+	require.Equal(t, []Provenance{
+		{Kind: KindSource, Key: "kubernetes_logs", Type: "kubernetes_logs",
+			Metadata: Metadata{Origin: "configForLogs", Team: "platform"}, Fragments: []string{"platform"}},
+		{Kind: KindSink, Key: "loki", Type: "loki",
+			Metadata: Metadata{Origin: "configForLogs", Team: "platform"}, Fragments: []string{"platform"}},
+	}, c.ComponentsBy("configForLogs"))
+	require.Len(t, c.ComponentsOwnedBy("payments"), 1)
+	require.Len(t, c.Provenance(), 4)
+
+	report, err := c.ProvenanceReport()
+	require.NoError(t, err)
+	require.Contains(t, report, `"origin": "configmap/payments/vector-audit"`)
+	require.Contains(t, report, `"key": "internal_metrics"`)
+
+	// Metadata is not rendered and goes away with the component.
+	rendered, err := c.JSON()
+	require.NoError(t, err)
+	require.NotContains(t, rendered, "payments")
+	require.NoError(t, c.RemoveSink("audit"))
+	_, ok = c.Metadata(KindSink, "audit")
+	require.False(t, ok)
+}
+
+func TestMergeMetadata(t *testing.T) {
+	t.Parallel()
+
+	base := NewConfig()
+	base.AddSource("logs", new(KubernetesLogsSource))
+	base.AddSource("journal", new(JournaldSource))
+	require.NoError(t, base.SetMetadata(KindSource, "logs", Metadata{Origin: "base", Team: "platform"}))
+	require.NoError(t, base.SetMetadata(KindSource, "journal", Metadata{Origin: "base", Team: "platform"}))
+
+	overlay := NewConfig()
+	overlay.AddSource("logs", new(KubernetesLogsSource))
+	overlay.AddSource("journal", new(JournaldSource))
+	require.NoError(t, overlay.SetMetadata(KindSource, "logs", Metadata{Description: "Pod logs"}))
+
+	merged, err := Merge(Fragment{Name: "base", Config: base},
+		Fragment{Name: "overlay", Config: overlay, Policy: ConflictDeepMerge})
+	require.NoError(t, err)
+	md, ok := merged.Metadata(KindSource, "logs")
+	require.True(t, ok)
+	require.Equal(t, Metadata{Origin: "base", Team: "platform", Description: "Pod logs"}, md)
+	md, ok = merged.Metadata(KindSource, "journal")
+	require.True(t, ok)
+	require.Equal(t, Metadata{Origin: "base", Team: "platform"}, md)
+
+	replaced, err := Merge(Fragment{Name: "base", Config: base},
+		Fragment{Name: "overlay", Config: overlay, Policy: ConflictLastWins})
+	require.NoError(t, err)
+	md, ok = replaced.Metadata(KindSource, "logs")
+	require.True(t, ok)
+	require.Equal(t, Metadata{Description: "Pod logs"}, md)
+	_, ok = replaced.Metadata(KindSource, "journal")
+	require.False(t, ok)
+}
@@ -0,0 +1,145 @@
+package vector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// Format is a configuration file format understood by vector.
+type Format string
+
+// Formats supported by vector.
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatTOML Format = "toml"
+)
+
+// ParseFormat returns the format with the given name or file extension.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimPrefix(s, ".")) {
+	case "json":
+		return FormatJSON, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	case "toml":
+		return FormatTOML, nil
+	default:
+		return "", fmt.Errorf("unknown config format '%s'", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *Format) UnmarshalText(text []byte) error {
+	parsed, err := ParseFormat(string(text))
+	if err != nil {
+		return err
+	}
+	*f = parsed
+	return nil
+}
+
+// Extension returns the file extension vector expects for the format, without the leading dot.
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// Render returns the configuration in the given format.
+func (c *Config) Render(format Format) (string, error) {
+	raw, err := c.JSON()
+	if err != nil {
+		return "", err
+	}
+	return renderDocument(format, []byte(raw))
+}
+
+// YAML returns the YAML representation of the configuration. Keys are sorted.
+func (c *Config) YAML() (string, error) {
+	return c.Render(FormatYAML)
+}
+
+// TOML returns the TOML representation of the configuration. Keys are sorted.
+func (c *Config) TOML() (string, error) {
+	return c.Render(FormatTOML)
+}
+
+// renderDocument returns a JSON document in the given format.
+func renderDocument(format Format, raw []byte) (string, error) {
+	switch format {
+	case FormatJSON:
+		return string(raw), nil
+	case FormatYAML:
+		return renderYAML(raw)
+	case FormatTOML:
+		return renderTOML(raw)
+	default:
+		return "", fmt.Errorf("unknown config format '%s'", format)
+	}
+}
+
+// renderYAML returns a JSON document as YAML.
+func renderYAML(raw []byte) (string, error) {
+	result, err := yaml.JSONToYAML(raw)
+	if err != nil {
+		return "", fmt.Errorf("error encoding config as yaml: %w", err)
+	}
+	return string(result), nil
+}
+
+// renderTOML returns a JSON document as TOML.
+func renderTOML(raw []byte) (string, error) {
+	// Numbers are decoded as integers where possible, as vector rejects floats for integer options.
+	doc, err := decodeJSON(raw)
+	if err != nil {
+		return "", err
+	}
+
+	result := bytes.NewBuffer(nil)
+	if err := toml.NewEncoder(result).Encode(tomlValue(normalizeNumbers(doc))); err != nil {
+		return "", fmt.Errorf("error encoding config as toml: %w", err)
+	}
+	return result.String(), nil
+}
+
+// decodeJSON decodes a JSON document, keeping numbers as json.Number so integers and floats can be told apart.
+func decodeJSON(raw []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	result := make(map[string]any)
+	if err := dec.Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding config: %w", err)
+	}
+	return result, nil
+}
+
+// tomlValue prepares a decoded JSON value for TOML encoding. TOML has no null, so null values are dropped.
+func tomlValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for k, item := range v {
+			if item == nil {
+				continue
+			}
+			result[k] = tomlValue(item)
+		}
+		return result
+	case []any:
+		result := make([]any, 0, len(v))
+		for _, item := range v {
+			if item == nil {
+				continue
+			}
+			result = append(result, tomlValue(item))
+		}
+		return result
+	default:
+		return v
+	}
+}
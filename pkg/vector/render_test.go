@@ -0,0 +1,90 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func renderTestConfig() *Config {
+	c := NewConfig()
+	c.AddSource("host", &HostMetricsSource{
+		ScrapeIntervalSecs: 15,
+		Collectors:         []string{"cpu", "memory"},
+	})
+	c.AddSink("out", &PrometheusRemoteWriteSink{
+		Inputs:   []string{"host"},
+		Endpoint: "http://mimir/api/v1/push",
+		Batch: &Batch{
+			MaxEvents:   10,
+			TimeoutSecs: 0.5,
+		},
+	})
+	return c
+}
+
+func TestYAML(t *testing.T) {
+	t.Parallel()
+
+	got, err := renderTestConfig().YAML()
+	require.NoError(t, err)
+	require.Equal(t, `sinks:
+  out:
+    batch:
+      max_events: 10
+      timeout_secs: 0.5
+    endpoint: http://mimir/api/v1/push
+    inputs:
+    - host
+    type: prometheus_remote_write
+sources:
+  host:
+    collectors:
+    - cpu
+    - memory
+    scrape_interval_secs: 15
+    type: host_metrics
+`, got)
+}
+
+func TestTOML(t *testing.T) {
+	t.Parallel()
+
+	got, err := renderTestConfig().TOML()
+	require.NoError(t, err)
+	require.Equal(t, `[sinks]
+[sinks.out]
+endpoint = 'http://mimir/api/v1/push'
+inputs = ['host']
+type = 'prometheus_remote_write'
+
+[sinks.out.batch]
+max_events = 10
+timeout_secs = 0.5
+
+[sources]
+[sources.host]
+collectors = ['cpu', 'memory']
+scrape_interval_secs = 15
+type = 'host_metrics'
+`, got)
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]Format{
+		"json":  FormatJSON,
+		".yml":  FormatYAML,
+		"YAML":  FormatYAML,
+		"toml":  FormatTOML,
+		".toml": FormatTOML,
+	} {
+		got, err := ParseFormat(in)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+
+	_, err := ParseFormat("xml")
+	require.EqualError(t, err, "unknown config format 'xml'")
+}
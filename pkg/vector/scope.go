@@ -0,0 +1,196 @@
+package vector
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// scopeSeparator joins the namespace of a scope and the keys of its components. Vector reserves "." for the outputs
+// of transforms, so it cannot be used.
+const scopeSeparator = "__"
+
+// ErrInvalidKey is returned when a component key breaks vector's naming rules.
+var ErrInvalidKey = errors.New("invalid component key")
+
+// keyPattern matches the characters vector allows in component keys.
+var keyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateKey checks that key is a valid vector component key: it must not be empty and may only hold letters,
+// digits, "_" and "-". Dots are reserved for the named outputs of transforms, e.g. "route.errors".
+func ValidateKey(key string) error {
+	if reason := keyProblem(key); reason != "" {
+		return fmt.Errorf("%w: %s", ErrInvalidKey, reason)
+	}
+	return nil
+}
+
+// keyProblem returns why key is not a valid vector component key, or an empty string if it is.
+func keyProblem(key string) string {
+	switch {
+	case key == "":
+		return "key is empty"
+	case !keyPattern.MatchString(key):
+		return fmt.Sprintf("'%s' may only hold letters, digits, '_' and '-'", key)
+	default:
+		return ""
+	}
+}
+
+// validateKeys checks the keys of every component against ValidateKey. The caller must hold c.mu.
+func (c *Config) validateKeys() []error {
+	errs := make([]error, 0)
+	for _, kind := range componentKinds {
+		for _, key := range sortedKeys(c.components(kind)) {
+			if reason := keyProblem(key); reason != "" {
+				errs = append(errs, &ValidationError{Kind: kind, Key: key, Err: ErrInvalidKey, Detail: reason})
+			}
+		}
+	}
+	return errs
+}
+
+// Scope is a view of a configuration whose component keys are prefixed with a namespace, so that fragments written
+// by different teams do not collide. Create one with Config.Scope.
+//
+// Inputs of the components added through a scope refer to other components of the scope and are prefixed as well,
+// including wildcards and named outputs. Components outside the scope have to be imported first:
+//
+//	s := c.Scope("team-a").Import("kubernetes_logs")
+//	s.AddTransform("parse_json", &vector.RemapTransform{Inputs: []string{"kubernetes_logs"}, Source: source})
+//	s.AddSink("loki", &vector.LokiSink{Inputs: []string{"parse_json"}})
+//
+// adds "team-a__parse_json", consuming "kubernetes_logs", and "team-a__loki", consuming "team-a__parse_json".
+type Scope struct {
+	config    *Config
+	namespace string
+	imports   []string
+}
+
+// Scope returns a view of the configuration prefixing component keys with namespace.
+func (c *Config) Scope(namespace string) *Scope {
+	return &Scope{
+		config:    c,
+		namespace: namespace,
+		imports:   make([]string, 0),
+	}
+}
+
+// Import lets the components of the scope consume the given components outside of it by their own keys.
+func (s *Scope) Import(keys ...string) *Scope {
+	s.imports = append(s.imports, keys...)
+	return s
+}
+
+// Key returns the key of a component of the scope in the configuration.
+func (s *Scope) Key(key string) string {
+	return s.namespace + scopeSeparator + key
+}
+
+// Keys returns the keys, without the namespace, of the components of the given kind in the scope, sorted.
+func (s *Scope) Keys(kind ComponentKind) []string {
+	s.config.mu.RLock()
+	defer s.config.mu.RUnlock()
+
+	prefix := s.Key("")
+	result := make([]string, 0)
+	for _, key := range sortedKeys(s.config.components(kind)) {
+		if local, ok := strings.CutPrefix(key, prefix); ok {
+			result = append(result, local)
+		}
+	}
+	return result
+}
+
+// AddSource adds the specified typed source to the scope under key.
+func (s *Scope) AddSource(key string, src Source) {
+	must(s.TryAddSource(key, src))
+}
+
+// TryAddSource is AddSource, returning an error instead of panicking.
+func (s *Scope) TryAddSource(key string, src Source) error {
+	cfg, err := componentMap(src.SourceType(), src)
+	if err != nil {
+		return err
+	}
+	return s.TryAddUntyped(KindSource, key, cfg)
+}
+
+// AddTransform adds the specified typed transform to the scope under key.
+func (s *Scope) AddTransform(key string, transform Transform) {
+	must(s.TryAddTransform(key, transform))
+}
+
+// TryAddTransform is AddTransform, returning an error instead of panicking.
+func (s *Scope) TryAddTransform(key string, transform Transform) error {
+	cfg, err := componentMap(transform.TransformType(), transform)
+	if err != nil {
+		return err
+	}
+	return s.TryAddUntyped(KindTransform, key, cfg)
+}
+
+// AddSink adds the specified typed sink to the scope under key.
+func (s *Scope) AddSink(key string, sink Sink) {
+	must(s.TryAddSink(key, sink))
+}
+
+// TryAddSink is AddSink, returning an error instead of panicking.
+func (s *Scope) TryAddSink(key string, sink Sink) error {
+	cfg, err := componentMap(sink.SinkType(), sink)
+	if err != nil {
+		return err
+	}
+	return s.TryAddUntyped(KindSink, key, cfg)
+}
+
+// AddComponent adds the specified typed component to the scope under key, as the kind of component it returns.
+func (s *Scope) AddComponent(key string, component Component) {
+	must(s.TryAddComponent(key, component))
+}
+
+// TryAddComponent is AddComponent, returning an error instead of panicking.
+func (s *Scope) TryAddComponent(key string, component Component) error {
+	cfg, err := customComponentMap(component)
+	if err != nil {
+		return err
+	}
+	return s.TryAddUntyped(component.Kind(), key, cfg)
+}
+
+// TryAddUntyped adds the specified configuration to the scope as a component of the given kind, returning
+// ErrInvalidKey if the namespace or key break vector's naming rules and ErrDuplicateKey if the key is taken.
+func (s *Scope) TryAddUntyped(kind ComponentKind, key string, cfg map[string]any) error {
+	if err := ValidateKey(s.namespace); err != nil {
+		return fmt.Errorf("scope '%s': %w", s.namespace, err)
+	}
+	if err := ValidateKey(key); err != nil {
+		return fmt.Errorf("scope '%s': %w", s.namespace, err)
+	}
+
+	cfg = copyComponent(cfg)
+	if inputs, ok := cfg["inputs"]; ok {
+		scoped := make([]string, 0)
+		for _, input := range componentInputs(map[string]any{"inputs": inputs}) {
+			scoped = append(scoped, s.input(input))
+		}
+		cfg["inputs"] = scoped
+	}
+	return s.config.add(kind, s.Key(key), cfg)
+}
+
+// Remove removes the component of the scope under key, returning ErrNotFound if there is none.
+func (s *Scope) Remove(kind ComponentKind, key string) error {
+	return s.config.remove(kind, s.Key(key))
+}
+
+// input returns the input referring to the component of the scope, unless it refers to an imported component.
+func (s *Scope) input(input string) string {
+	key, _, _ := strings.Cut(input, ".")
+	if slices.Contains(s.imports, key) {
+		return input
+	}
+	return s.Key(input)
+}
@@ -0,0 +1,65 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateKey(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateKey("parse_json"))
+	require.NoError(t, ValidateKey("team-a__parse-json2"))
+	require.ErrorIs(t, ValidateKey(""), ErrInvalidKey)
+	require.EqualError(t, ValidateKey("route.errors"),
+		"invalid component key: 'route.errors' may only hold letters, digits, '_' and '-'")
+	require.ErrorIs(t, ValidateKey("parse json"), ErrInvalidKey)
+
+	c := NewConfig()
+	c.AddSourceUntyped("logs in", map[string]any{"type": "stdin"})
+	c.AddSinkUntyped("out", map[string]any{"type": "blackhole", "inputs": []string{"logs in"}})
+	err := c.Validate()
+	require.ErrorIs(t, err, ErrInvalidKey)
+	require.EqualError(t, err, `source "logs in": invalid component key: 'logs in' may only hold letters, digits, '_' and '-'`)
+}
+
+func TestScope(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddSource("kubernetes_logs", new(KubernetesLogsSource))
+
+	for _, team := range []string{"team-a", "team-b"} {
+		s := c.Scope(team).Import("kubernetes_logs")
+		s.AddTransform("parse_json", &RemapTransform{
+			Inputs: []string{"kubernetes_logs"},
+			Source: ". = parse_json!(.message)",
+		})
+		s.AddTransform("route", &RouteTransform{
+			Inputs: []string{"parse_*"},
+			Route:  map[string]Condition{"errors": VRLCondition(`.level == "error"`)},
+		})
+		s.AddSink("alerts", &BlackholeSink{Inputs: []string{"route.errors"}})
+		s.AddSink("archive", &BlackholeSink{Inputs: []string{"route._unmatched"}})
+	}
+	require.NoError(t, c.Validate())
+
+	inputs := func(kind ComponentKind, key string) []string {
+		cfg, ok := c.get(kind, key)
+		require.True(t, ok, key)
+		return componentInputs(cfg)
+	}
+	require.Equal(t, []string{"kubernetes_logs"}, inputs(KindTransform, "team-a__parse_json"))
+	require.Equal(t, []string{"team-a__parse_*"}, inputs(KindTransform, "team-a__route"))
+	require.Equal(t, []string{"team-b__route.errors"}, inputs(KindSink, "team-b__alerts"))
+
+	s := c.Scope("team-a")
+	require.Equal(t, []string{"parse_json", "route"}, s.Keys(KindTransform))
+	require.ErrorIs(t, s.TryAddSink("alerts", new(BlackholeSink)), ErrDuplicateKey)
+	require.ErrorIs(t, s.TryAddSink("alerts.copy", new(BlackholeSink)), ErrInvalidKey)
+	require.ErrorIs(t, c.Scope("team.a").TryAddSink("alerts", new(BlackholeSink)), ErrInvalidKey)
+
+	require.NoError(t, s.Remove(KindSink, "archive"))
+	require.Equal(t, []string{"alerts"}, s.Keys(KindSink))
+}
@@ -0,0 +1,184 @@
+package vector
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// secretReferencePattern matches a reference to a secret, capturing the backend and the secret key.
+var secretReferencePattern = regexp.MustCompile(`SECRET\[([[:word:]]+)\.([[:word:].\-/]+)\]`)
+
+// SecretBackend is a typed vector secret backend configuration.
+type SecretBackend interface {
+	// SecretBackendType returns the vector type of the secret backend.
+	SecretBackendType() string
+}
+
+// AddTypedSecretBackend adds the specified typed secret backend under backendName.
+func (c *Config) AddTypedSecretBackend(backendName string, backend SecretBackend) {
+	must(c.TryAddTypedSecretBackend(backendName, backend))
+}
+
+// TryAddTypedSecretBackend is AddTypedSecretBackend, returning an error instead of panicking.
+func (c *Config) TryAddTypedSecretBackend(backendName string, backend SecretBackend) error {
+	cfg, err := componentMap(backend.SecretBackendType(), backend)
+	if err != nil {
+		return err
+	}
+	return c.TryAddSecretBackend(backendName, cfg)
+}
+
+// ReplaceTypedSecretBackend replaces the secret backend under backendName with a typed backend, returning
+// ErrNotFound if there is none.
+func (c *Config) ReplaceTypedSecretBackend(backendName string, backend SecretBackend) error {
+	cfg, err := componentMap(backend.SecretBackendType(), backend)
+	if err != nil {
+		return err
+	}
+	return c.ReplaceSecretBackend(backendName, cfg)
+}
+
+// ExecSecretBackend retrieves secrets by running a command.
+//
+// https://vector.dev/docs/reference/configuration/global-options/#secret.exec
+type ExecSecretBackend struct {
+	Command []string `json:"command"`
+	Timeout int      `json:"timeout,omitempty"`
+}
+
+// SecretBackendType implements SecretBackend.
+func (*ExecSecretBackend) SecretBackendType() string { return "exec" }
+
+// FileSecretBackend reads secrets from a JSON file.
+//
+// https://vector.dev/docs/reference/configuration/global-options/#secret.file
+type FileSecretBackend struct {
+	Path string `json:"path"`
+}
+
+// SecretBackendType implements SecretBackend.
+func (*FileSecretBackend) SecretBackendType() string { return "file" }
+
+// DirectorySecretBackend reads secrets from the files of a directory, the file name being the secret key.
+//
+// https://vector.dev/docs/reference/configuration/global-options/#secret.directory
+type DirectorySecretBackend struct {
+	Path                     string `json:"path"`
+	RemoveTrailingWhitespace *bool  `json:"remove_trailing_whitespace,omitempty"`
+}
+
+// SecretBackendType implements SecretBackend.
+func (*DirectorySecretBackend) SecretBackendType() string { return "directory" }
+
+// AWSSecretsManagerSecretBackend retrieves secrets from an AWS Secrets Manager secret holding a JSON object.
+//
+// https://vector.dev/docs/reference/configuration/global-options/#secret.aws_secrets_manager
+type AWSSecretsManagerSecretBackend struct {
+	Auth     *AWSAuth `json:"auth,omitempty"`
+	Endpoint string   `json:"endpoint,omitempty"`
+	Region   string   `json:"region,omitempty"`
+	SecretID string   `json:"secret_id"`
+	TLS      *TLS     `json:"tls,omitempty"`
+}
+
+// SecretBackendType implements SecretBackend.
+func (*AWSSecretsManagerSecretBackend) SecretBackendType() string { return "aws_secrets_manager" }
+
+// Secret returns the string referring to the secret key of the secret backend backendName, e.g.
+// "SECRET[vault.password]". Vector replaces the reference with the secret when loading the configuration.
+func Secret(backendName, key string) string {
+	return fmt.Sprintf("SECRET[%s.%s]", backendName, key)
+}
+
+// SecretReference is a reference to a secret found in a configuration.
+type SecretReference struct {
+	// Backend is the name of the secret backend holding the secret.
+	Backend string
+
+	// Key is the key of the secret within the backend.
+	Key string
+
+	// Kind is the kind of the component holding the reference.
+	Kind ComponentKind
+
+	// Component is the key of the component holding the reference, or the name of the global option.
+	Component string
+}
+
+// String returns the reference as written in the configuration.
+func (r SecretReference) String() string {
+	return Secret(r.Backend, r.Key)
+}
+
+// SecretReferences returns every secret reference in the configuration, sorted by backend, key, kind and
+// component. A secret referenced by several components is listed once per component.
+func (c *Config) SecretReferences() []SecretReference {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.secretReferences()
+}
+
+// UndeclaredSecretBackends returns the secret references whose backend is not declared in the configuration.
+func (c *Config) UndeclaredSecretBackends() []SecretReference {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.undeclaredSecretBackends()
+}
+
+// secretReferences returns every secret reference in the configuration. The caller must hold c.mu.
+func (c *Config) secretReferences() []SecretReference {
+	result := make([]SecretReference, 0)
+	c.walkStrings(func(kind ComponentKind, component string, s string) {
+		if kind == KindSecretBackend {
+			// Vector does not resolve secrets in the configuration of secret backends.
+			return
+		}
+		for _, match := range secretReferencePattern.FindAllStringSubmatch(s, -1) {
+			result = append(result, SecretReference{
+				Backend:   match[1],
+				Key:       match[2],
+				Kind:      kind,
+				Component: component,
+			})
+		}
+	})
+
+	slices.SortFunc(result, func(a, b SecretReference) int {
+		return cmp.Or(
+			cmp.Compare(a.Backend, b.Backend),
+			cmp.Compare(a.Key, b.Key),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Component, b.Component),
+		)
+	})
+	return slices.Compact(result)
+}
+
+// undeclaredSecretBackends returns the secret references whose backend is not declared. The caller must hold c.mu.
+func (c *Config) undeclaredSecretBackends() []SecretReference {
+	result := make([]SecretReference, 0)
+	for _, ref := range c.secretReferences() {
+		if _, ok := c.internal.SecretBackends[ref.Backend]; !ok {
+			result = append(result, ref)
+		}
+	}
+	return result
+}
+
+// validateSecretReferences checks that the secret backends referred to are declared. The caller must hold c.mu.
+func (c *Config) validateSecretReferences() []error {
+	errs := make([]error, 0)
+	for _, ref := range c.undeclaredSecretBackends() {
+		errs = append(errs, &ValidationError{
+			Kind:   ref.Kind,
+			Key:    ref.Component,
+			Err:    ErrUndeclaredSecretBackend,
+			Detail: ref.String(),
+		})
+	}
+	return errs
+}
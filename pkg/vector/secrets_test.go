@@ -0,0 +1,75 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypedSecretBackends(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddTypedSecretBackend("exec", &ExecSecretBackend{Command: []string{"/bin/secrets"}, Timeout: 5})
+	c.AddTypedSecretBackend("file", &FileSecretBackend{Path: "/etc/vector/secrets.json"})
+	c.AddTypedSecretBackend("dir", &DirectorySecretBackend{Path: "/var/run/secrets/vector"})
+	c.AddTypedSecretBackend("aws", &AWSSecretsManagerSecretBackend{SecretID: "vector", Region: "eu-west-1"})
+
+	secret, err := c.JSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"secret": {
+			"exec": {"type": "exec", "command": ["/bin/secrets"], "timeout": 5},
+			"file": {"type": "file", "path": "/etc/vector/secrets.json"},
+			"dir": {"type": "directory", "path": "/var/run/secrets/vector"},
+			"aws": {"type": "aws_secrets_manager", "secret_id": "vector", "region": "eu-west-1"}
+		},
+		"sources": {},
+		"sinks": {}
+	}`, secret)
+
+	require.ErrorIs(t, c.TryAddTypedSecretBackend("dir", new(DirectorySecretBackend)), ErrDuplicateKey)
+	require.NoError(t, c.ReplaceTypedSecretBackend("dir", &DirectorySecretBackend{Path: "/secrets"}))
+	backend, ok := c.GetSecretBackend("dir")
+	require.True(t, ok)
+	require.Equal(t, "/secrets", backend["path"])
+}
+
+func TestSecretReferences(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "SECRET[vault.es_password]", Secret("vault", "es_password"))
+
+	c := NewConfig()
+	c.AddTypedSecretBackend("vault", &DirectorySecretBackend{Path: "/var/run/secrets/vector"})
+	c.AddSource("logs", new(KubernetesLogsSource))
+	c.AddSink("es", &ElasticsearchSink{
+		Inputs:    []string{"logs"},
+		Endpoints: []string{"https://es:9200"},
+		Auth: &HTTPAuth{
+			Strategy: "basic",
+			Username: Secret("vault", "es_user"),
+			Password: Secret("vault", "es_password"),
+		},
+	})
+	c.AddSink("kafka", &KafkaSink{
+		Inputs:           []string{"logs"},
+		BootstrapServers: "kafka:9092",
+		Topic:            "logs",
+		SASL:             &KafkaSASL{Password: Secret("aws", "kafka/password")},
+	})
+
+	require.Equal(t, []SecretReference{
+		{Backend: "aws", Key: "kafka/password", Kind: KindSink, Component: "kafka"},
+		{Backend: "vault", Key: "es_password", Kind: KindSink, Component: "es"},
+		{Backend: "vault", Key: "es_user", Kind: KindSink, Component: "es"},
+	}, c.SecretReferences())
+
+	undeclared := c.UndeclaredSecretBackends()
+	require.Len(t, undeclared, 1)
+	require.Equal(t, "SECRET[aws.kafka/password]", undeclared[0].String())
+
+	err := c.Validate()
+	require.ErrorIs(t, err, ErrUndeclaredSecretBackend)
+	require.EqualError(t, err, `sink "kafka": secret backend is not declared: SECRET[aws.kafka/password]`)
+}
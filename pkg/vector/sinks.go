@@ -0,0 +1,302 @@
+package vector
+
+// Sink is a typed vector sink configuration.
+type Sink interface {
+	// SinkType returns the vector type of the sink.
+	SinkType() string
+}
+
+// AddSink adds the specified typed sink under key.
+func (c *Config) AddSink(key string, s Sink) {
+	must(c.TryAddSink(key, s))
+}
+
+// TryAddSink is AddSink, returning an error instead of panicking.
+func (c *Config) TryAddSink(key string, s Sink) error {
+	cfg, err := componentMap(s.SinkType(), s)
+	if err != nil {
+		return err
+	}
+	return c.TryAddSinkUntyped(key, cfg)
+}
+
+// ReplaceSink replaces the sink under key with a typed sink, returning ErrNotFound if there is none.
+func (c *Config) ReplaceSink(key string, s Sink) error {
+	cfg, err := componentMap(s.SinkType(), s)
+	if err != nil {
+		return err
+	}
+	return c.ReplaceSinkUntyped(key, cfg)
+}
+
+// LokiSink sends logs to Grafana Loki.
+//
+// https://vector.dev/docs/reference/configuration/sinks/loki/
+type LokiSink struct {
+	Acknowledgements   *Acknowledgements `json:"acknowledgements,omitempty"`
+	Auth               *HTTPAuth         `json:"auth,omitempty"`
+	Batch              *Batch            `json:"batch,omitempty"`
+	Buffer             *Buffer           `json:"buffer,omitempty"`
+	Compression        string            `json:"compression,omitempty"`
+	Encoding           Encoding          `json:"encoding"`
+	Endpoint           string            `json:"endpoint"`
+	Healthcheck        *Healthcheck      `json:"healthcheck,omitempty"`
+	Inputs             []string          `json:"inputs"`
+	Labels             map[string]string `json:"labels,omitempty"`
+	OutOfOrderAction   string            `json:"out_of_order_action,omitempty"`
+	Path               string            `json:"path,omitempty"`
+	RemoveLabelFields  *bool             `json:"remove_label_fields,omitempty"`
+	RemoveTimestamp    *bool             `json:"remove_timestamp,omitempty"`
+	Request            *Request          `json:"request,omitempty"`
+	StructuredMetadata map[string]string `json:"structured_metadata,omitempty"`
+	TenantID           string            `json:"tenant_id,omitempty"`
+	TLS                *TLS              `json:"tls,omitempty"`
+}
+
+// SinkType implements Sink.
+func (*LokiSink) SinkType() string { return "loki" }
+
+// PrometheusExporterSink exposes metrics on an endpoint for prometheus to scrape.
+//
+// https://vector.dev/docs/reference/configuration/sinks/prometheus_exporter/
+type PrometheusExporterSink struct {
+	Acknowledgements         *Acknowledgements `json:"acknowledgements,omitempty"`
+	Address                  string            `json:"address,omitempty"`
+	Auth                     *HTTPAuth         `json:"auth,omitempty"`
+	Buckets                  []float64         `json:"buckets,omitempty"`
+	Buffer                   *Buffer           `json:"buffer,omitempty"`
+	DefaultNamespace         string            `json:"default_namespace,omitempty"`
+	DistributionsAsSummaries *bool             `json:"distributions_as_summaries,omitempty"`
+	FlushPeriodSecs          int               `json:"flush_period_secs,omitempty"`
+	Healthcheck              *Healthcheck      `json:"healthcheck,omitempty"`
+	Inputs                   []string          `json:"inputs"`
+	Quantiles                []float64         `json:"quantiles,omitempty"`
+	TLS                      *TLS              `json:"tls,omitempty"`
+}
+
+// SinkType implements Sink.
+func (*PrometheusExporterSink) SinkType() string { return "prometheus_exporter" }
+
+// PrometheusRemoteWriteSink pushes metrics to a prometheus remote write endpoint.
+//
+// https://vector.dev/docs/reference/configuration/sinks/prometheus_remote_write/
+type PrometheusRemoteWriteSink struct {
+	Acknowledgements *Acknowledgements `json:"acknowledgements,omitempty"`
+	Auth             *HTTPAuth         `json:"auth,omitempty"`
+	Batch            *Batch            `json:"batch,omitempty"`
+	Buffer           *Buffer           `json:"buffer,omitempty"`
+	Compression      string            `json:"compression,omitempty"`
+	DefaultNamespace string            `json:"default_namespace,omitempty"`
+	Endpoint         string            `json:"endpoint"`
+	Healthcheck      *Healthcheck      `json:"healthcheck,omitempty"`
+	Inputs           []string          `json:"inputs"`
+	Request          *Request          `json:"request,omitempty"`
+	TenantID         string            `json:"tenant_id,omitempty"`
+	TLS              *TLS              `json:"tls,omitempty"`
+}
+
+// SinkType implements Sink.
+func (*PrometheusRemoteWriteSink) SinkType() string { return "prometheus_remote_write" }
+
+// ElasticsearchSink indexes events into Elasticsearch.
+//
+// https://vector.dev/docs/reference/configuration/sinks/elasticsearch/
+type ElasticsearchSink struct {
+	Acknowledgements *Acknowledgements  `json:"acknowledgements,omitempty"`
+	APIVersion       string             `json:"api_version,omitempty"`
+	Auth             *HTTPAuth          `json:"auth,omitempty"`
+	Batch            *Batch             `json:"batch,omitempty"`
+	Buffer           *Buffer            `json:"buffer,omitempty"`
+	Bulk             *ElasticsearchBulk `json:"bulk,omitempty"`
+	Compression      string             `json:"compression,omitempty"`
+	Encoding         *Encoding          `json:"encoding,omitempty"`
+	Endpoints        []string           `json:"endpoints"`
+	Healthcheck      *Healthcheck       `json:"healthcheck,omitempty"`
+	IDKey            string             `json:"id_key,omitempty"`
+	Inputs           []string           `json:"inputs"`
+	Mode             string             `json:"mode,omitempty"`
+	Pipeline         string             `json:"pipeline,omitempty"`
+	Request          *Request           `json:"request,omitempty"`
+	TLS              *TLS               `json:"tls,omitempty"`
+}
+
+// SinkType implements Sink.
+func (*ElasticsearchSink) SinkType() string { return "elasticsearch" }
+
+// ElasticsearchBulk configures the bulk API used by the elasticsearch sink.
+type ElasticsearchBulk struct {
+	Action string `json:"action,omitempty"`
+	Index  string `json:"index,omitempty"`
+}
+
+// HTTPSink sends batches of events to an HTTP endpoint.
+//
+// https://vector.dev/docs/reference/configuration/sinks/http/
+type HTTPSink struct {
+	Acknowledgements *Acknowledgements `json:"acknowledgements,omitempty"`
+	Auth             *HTTPAuth         `json:"auth,omitempty"`
+	Batch            *Batch            `json:"batch,omitempty"`
+	Buffer           *Buffer           `json:"buffer,omitempty"`
+	Compression      string            `json:"compression,omitempty"`
+	Encoding         Encoding          `json:"encoding"`
+	Framing          *Framing          `json:"framing,omitempty"`
+	Healthcheck      *Healthcheck      `json:"healthcheck,omitempty"`
+	Inputs           []string          `json:"inputs"`
+	Method           string            `json:"method,omitempty"`
+	PayloadPrefix    string            `json:"payload_prefix,omitempty"`
+	PayloadSuffix    string            `json:"payload_suffix,omitempty"`
+	Request          *Request          `json:"request,omitempty"`
+	TLS              *TLS              `json:"tls,omitempty"`
+	URI              string            `json:"uri"`
+}
+
+// SinkType implements Sink.
+func (*HTTPSink) SinkType() string { return "http" }
+
+// KafkaSink publishes events to a kafka topic.
+//
+// https://vector.dev/docs/reference/configuration/sinks/kafka/
+type KafkaSink struct {
+	Acknowledgements  *Acknowledgements `json:"acknowledgements,omitempty"`
+	BootstrapServers  string            `json:"bootstrap_servers"`
+	Buffer            *Buffer           `json:"buffer,omitempty"`
+	Compression       string            `json:"compression,omitempty"`
+	Encoding          Encoding          `json:"encoding"`
+	HeadersKey        string            `json:"headers_key,omitempty"`
+	Healthcheck       *Healthcheck      `json:"healthcheck,omitempty"`
+	Inputs            []string          `json:"inputs"`
+	KeyField          string            `json:"key_field,omitempty"`
+	LibrdkafkaOptions map[string]string `json:"librdkafka_options,omitempty"`
+	MessageTimeoutMs  int               `json:"message_timeout_ms,omitempty"`
+	SASL              *KafkaSASL        `json:"sasl,omitempty"`
+	SocketTimeoutMs   int               `json:"socket_timeout_ms,omitempty"`
+	TLS               *TLS              `json:"tls,omitempty"`
+	Topic             string            `json:"topic"`
+}
+
+// SinkType implements Sink.
+func (*KafkaSink) SinkType() string { return "kafka" }
+
+// KafkaSASL configures SASL authentication for the kafka sink.
+type KafkaSASL struct {
+	Enabled   *bool  `json:"enabled,omitempty"`
+	Mechanism string `json:"mechanism,omitempty"`
+	Password  string `json:"password,omitempty"`
+	Username  string `json:"username,omitempty"`
+}
+
+// AWSS3Sink writes batches of events to objects in an S3 bucket.
+//
+// https://vector.dev/docs/reference/configuration/sinks/aws_s3/
+type AWSS3Sink struct {
+	Acknowledgements     *Acknowledgements `json:"acknowledgements,omitempty"`
+	ACL                  string            `json:"acl,omitempty"`
+	Auth                 *AWSAuth          `json:"auth,omitempty"`
+	Batch                *Batch            `json:"batch,omitempty"`
+	Bucket               string            `json:"bucket"`
+	Buffer               *Buffer           `json:"buffer,omitempty"`
+	Compression          string            `json:"compression,omitempty"`
+	Encoding             Encoding          `json:"encoding"`
+	Endpoint             string            `json:"endpoint,omitempty"`
+	FilenameAppendUUID   *bool             `json:"filename_append_uuid,omitempty"`
+	FilenameExtension    string            `json:"filename_extension,omitempty"`
+	FilenameTimeFormat   string            `json:"filename_time_format,omitempty"`
+	Framing              *Framing          `json:"framing,omitempty"`
+	Healthcheck          *Healthcheck      `json:"healthcheck,omitempty"`
+	Inputs               []string          `json:"inputs"`
+	KeyPrefix            string            `json:"key_prefix,omitempty"`
+	Region               string            `json:"region,omitempty"`
+	Request              *Request          `json:"request,omitempty"`
+	ServerSideEncryption string            `json:"server_side_encryption,omitempty"`
+	StorageClass         string            `json:"storage_class,omitempty"`
+	TLS                  *TLS              `json:"tls,omitempty"`
+}
+
+// SinkType implements Sink.
+func (*AWSS3Sink) SinkType() string { return "aws_s3" }
+
+// AWSAuth configures authentication against AWS.
+type AWSAuth struct {
+	AccessKeyID     string `json:"access_key_id,omitempty"`
+	AssumeRole      string `json:"assume_role,omitempty"`
+	CredentialsFile string `json:"credentials_file,omitempty"`
+	Profile         string `json:"profile,omitempty"`
+	Region          string `json:"region,omitempty"`
+	SecretAccessKey string `json:"secret_access_key,omitempty"`
+}
+
+// ConsoleSink writes events to stdout or stderr.
+//
+// https://vector.dev/docs/reference/configuration/sinks/console/
+type ConsoleSink struct {
+	Acknowledgements *Acknowledgements `json:"acknowledgements,omitempty"`
+	Buffer           *Buffer           `json:"buffer,omitempty"`
+	Encoding         Encoding          `json:"encoding"`
+	Framing          *Framing          `json:"framing,omitempty"`
+	Healthcheck      *Healthcheck      `json:"healthcheck,omitempty"`
+	Inputs           []string          `json:"inputs"`
+	Target           string            `json:"target,omitempty"`
+}
+
+// SinkType implements Sink.
+func (*ConsoleSink) SinkType() string { return "console" }
+
+// BlackholeSink discards all events.
+//
+// https://vector.dev/docs/reference/configuration/sinks/blackhole/
+type BlackholeSink struct {
+	Acknowledgements  *Acknowledgements `json:"acknowledgements,omitempty"`
+	Buffer            *Buffer           `json:"buffer,omitempty"`
+	Healthcheck       *Healthcheck      `json:"healthcheck,omitempty"`
+	Inputs            []string          `json:"inputs"`
+	PrintIntervalSecs int               `json:"print_interval_secs,omitempty"`
+	Rate              int               `json:"rate,omitempty"`
+}
+
+// SinkType implements Sink.
+func (*BlackholeSink) SinkType() string { return "blackhole" }
+
+// VectorSink forwards events to another vector instance.
+//
+// https://vector.dev/docs/reference/configuration/sinks/vector/
+type VectorSink struct {
+	Acknowledgements *Acknowledgements `json:"acknowledgements,omitempty"`
+	Address          string            `json:"address"`
+	Batch            *Batch            `json:"batch,omitempty"`
+	Buffer           *Buffer           `json:"buffer,omitempty"`
+	Compression      *bool             `json:"compression,omitempty"`
+	Healthcheck      *Healthcheck      `json:"healthcheck,omitempty"`
+	Inputs           []string          `json:"inputs"`
+	Request          *Request          `json:"request,omitempty"`
+	TLS              *TLS              `json:"tls,omitempty"`
+}
+
+// SinkType implements Sink.
+func (*VectorSink) SinkType() string { return "vector" }
+
+// OpenTelemetrySink sends events to an OTLP endpoint.
+//
+// https://vector.dev/docs/reference/configuration/sinks/opentelemetry/
+type OpenTelemetrySink struct {
+	Acknowledgements *Acknowledgements     `json:"acknowledgements,omitempty"`
+	Buffer           *Buffer               `json:"buffer,omitempty"`
+	Healthcheck      *Healthcheck          `json:"healthcheck,omitempty"`
+	Inputs           []string              `json:"inputs"`
+	Protocol         OpenTelemetryProtocol `json:"protocol"`
+}
+
+// SinkType implements Sink.
+func (*OpenTelemetrySink) SinkType() string { return "opentelemetry" }
+
+// OpenTelemetryProtocol configures the transport used by the opentelemetry sink.
+type OpenTelemetryProtocol struct {
+	Auth        *HTTPAuth `json:"auth,omitempty"`
+	Batch       *Batch    `json:"batch,omitempty"`
+	Compression string    `json:"compression,omitempty"`
+	Encoding    Encoding  `json:"encoding"`
+	Method      string    `json:"method,omitempty"`
+	Request     *Request  `json:"request,omitempty"`
+	TLS         *TLS      `json:"tls,omitempty"`
+	Type        string    `json:"type"`
+	URI         string    `json:"uri"`
+}
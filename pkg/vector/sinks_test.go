@@ -0,0 +1,57 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddSink(t *testing.T) {
+	t.Parallel()
+
+	vCfg := NewConfig()
+	vCfg.AddSink("remote_write", &PrometheusRemoteWriteSink{
+		Inputs:   []string{"host_metrics"},
+		Endpoint: "http://mimir/api/v1/push",
+		Batch: &Batch{
+			MaxEvents:   1000,
+			TimeoutSecs: 0.5,
+		},
+		Buffer: &Buffer{
+			Type:     BufferTypeDisk,
+			MaxSize:  268435488,
+			WhenFull: "block",
+		},
+		Request: &Request{
+			Concurrency: FixedConcurrency(4),
+		},
+		TLS: &TLS{
+			CAFile: "/etc/ssl/ca.crt",
+		},
+	})
+	vCfg.AddSinkUntyped("debug", map[string]any{
+		"type":   "blackhole",
+		"inputs": []string{"host_metrics"},
+	})
+
+	got, err := vCfg.JSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"sources": {},
+		"sinks": {
+			"remote_write": {
+				"type": "prometheus_remote_write",
+				"inputs": ["host_metrics"],
+				"endpoint": "http://mimir/api/v1/push",
+				"batch": {"max_events": 1000, "timeout_secs": 0.5},
+				"buffer": {"type": "disk", "max_size": 268435488, "when_full": "block"},
+				"request": {"concurrency": 4},
+				"tls": {"ca_file": "/etc/ssl/ca.crt"}
+			},
+			"debug": {
+				"type": "blackhole",
+				"inputs": ["host_metrics"]
+			}
+		}
+	}`, got)
+}
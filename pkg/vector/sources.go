@@ -0,0 +1,267 @@
+package vector
+
+// Source is a typed vector source configuration.
+type Source interface {
+	// SourceType returns the vector type of the source.
+	SourceType() string
+}
+
+// AddSource adds the specified typed source under key.
+func (c *Config) AddSource(key string, src Source) {
+	must(c.TryAddSource(key, src))
+}
+
+// TryAddSource is AddSource, returning an error instead of panicking.
+func (c *Config) TryAddSource(key string, src Source) error {
+	cfg, err := componentMap(src.SourceType(), src)
+	if err != nil {
+		return err
+	}
+	return c.TryAddSourceUntyped(key, cfg)
+}
+
+// ReplaceSource replaces the source under key with a typed source, returning ErrNotFound if there is none.
+func (c *Config) ReplaceSource(key string, src Source) error {
+	cfg, err := componentMap(src.SourceType(), src)
+	if err != nil {
+		return err
+	}
+	return c.ReplaceSourceUntyped(key, cfg)
+}
+
+// KubernetesLogsSource collects pod logs from the node vector is running on.
+//
+// https://vector.dev/docs/reference/configuration/sources/kubernetes_logs/
+type KubernetesLogsSource struct {
+	AutoPartialMerge            *bool             `json:"auto_partial_merge,omitempty"`
+	DataDir                     string            `json:"data_dir,omitempty"`
+	ExcludePathsGlobPatterns    []string          `json:"exclude_paths_glob_patterns,omitempty"`
+	ExtraFieldSelector          string            `json:"extra_field_selector,omitempty"`
+	ExtraLabelSelector          string            `json:"extra_label_selector,omitempty"`
+	ExtraNamespaceLabelSelector string            `json:"extra_namespace_label_selector,omitempty"`
+	GlobMinimumCooldownMs       int               `json:"glob_minimum_cooldown_ms,omitempty"`
+	IncludePathsGlobPatterns    []string          `json:"include_paths_glob_patterns,omitempty"`
+	IngestionTimestampField     string            `json:"ingestion_timestamp_field,omitempty"`
+	KubeConfigFile              string            `json:"kube_config_file,omitempty"`
+	MaxLineBytes                int               `json:"max_line_bytes,omitempty"`
+	MaxReadBytes                int               `json:"max_read_bytes,omitempty"`
+	NamespaceAnnotationFields   map[string]string `json:"namespace_annotation_fields,omitempty"`
+	NodeAnnotationFields        map[string]string `json:"node_annotation_fields,omitempty"`
+	OldestFirst                 *bool             `json:"oldest_first,omitempty"`
+	PodAnnotationFields         map[string]string `json:"pod_annotation_fields,omitempty"`
+	ReadFrom                    string            `json:"read_from,omitempty"`
+	SelfNodeName                string            `json:"self_node_name,omitempty"`
+	Timezone                    string            `json:"timezone,omitempty"`
+	UseAPIServerCache           *bool             `json:"use_apiserver_cache,omitempty"`
+}
+
+// SourceType implements Source.
+func (*KubernetesLogsSource) SourceType() string { return "kubernetes_logs" }
+
+// HostMetricsSource collects metrics from the host vector is running on.
+//
+// https://vector.dev/docs/reference/configuration/sources/host_metrics/
+type HostMetricsSource struct {
+	Cgroups            *HostMetricsCgroups     `json:"cgroups,omitempty"`
+	Collectors         []string                `json:"collectors,omitempty"`
+	Disk               *HostMetricsDevices     `json:"disk,omitempty"`
+	Filesystem         *HostMetricsFilesystem  `json:"filesystem,omitempty"`
+	Namespace          string                  `json:"namespace,omitempty"`
+	Network            *HostMetricsDevices     `json:"network,omitempty"`
+	Process            *HostMetricsProcess     `json:"process,omitempty"`
+	ScrapeIntervalSecs float64                 `json:"scrape_interval_secs,omitempty"`
+	Tags               *HostMetricsCollectTags `json:"tags,omitempty"`
+}
+
+// SourceType implements Source.
+func (*HostMetricsSource) SourceType() string { return "host_metrics" }
+
+// HostMetricsCgroups configures the cgroups collector of the host_metrics source.
+type HostMetricsCgroups struct {
+	Base    *IncludeExclude `json:"base,omitempty"`
+	BaseDir string          `json:"base_dir,omitempty"`
+	Groups  *IncludeExclude `json:"groups,omitempty"`
+	Levels  int             `json:"levels,omitempty"`
+}
+
+// HostMetricsDevices configures the disk and network collectors of the host_metrics source.
+type HostMetricsDevices struct {
+	Devices *IncludeExclude `json:"devices,omitempty"`
+}
+
+// HostMetricsFilesystem configures the filesystem collector of the host_metrics source.
+type HostMetricsFilesystem struct {
+	Devices     *IncludeExclude `json:"devices,omitempty"`
+	Filesystems *IncludeExclude `json:"filesystems,omitempty"`
+	Mountpoints *IncludeExclude `json:"mountpoints,omitempty"`
+}
+
+// HostMetricsProcess configures the process collector of the host_metrics source.
+type HostMetricsProcess struct {
+	Processes *IncludeExclude `json:"processes,omitempty"`
+}
+
+// HostMetricsCollectTags configures the tags added to metrics by the host_metrics source.
+type HostMetricsCollectTags struct {
+	HostKey string `json:"host_key,omitempty"`
+}
+
+// InternalMetricsSource exposes vector's own metrics.
+//
+// https://vector.dev/docs/reference/configuration/sources/internal_metrics/
+type InternalMetricsSource struct {
+	Namespace          string               `json:"namespace,omitempty"`
+	ScrapeIntervalSecs float64              `json:"scrape_interval_secs,omitempty"`
+	Tags               *InternalMetricsTags `json:"tags,omitempty"`
+}
+
+// SourceType implements Source.
+func (*InternalMetricsSource) SourceType() string { return "internal_metrics" }
+
+// InternalMetricsTags configures the tags added to metrics by the internal_metrics source.
+type InternalMetricsTags struct {
+	HostKey string `json:"host_key,omitempty"`
+	PidKey  string `json:"pid_key,omitempty"`
+}
+
+// FileSource tails files on the local filesystem.
+//
+// https://vector.dev/docs/reference/configuration/sources/file/
+type FileSource struct {
+	DataDir               string           `json:"data_dir,omitempty"`
+	Encoding              *FileEncoding    `json:"encoding,omitempty"`
+	Exclude               []string         `json:"exclude,omitempty"`
+	FileKey               string           `json:"file_key,omitempty"`
+	Fingerprint           *FileFingerprint `json:"fingerprint,omitempty"`
+	GlobMinimumCooldownMs int              `json:"glob_minimum_cooldown_ms,omitempty"`
+	HostKey               string           `json:"host_key,omitempty"`
+	IgnoreCheckpoints     *bool            `json:"ignore_checkpoints,omitempty"`
+	IgnoreOlderSecs       int              `json:"ignore_older_secs,omitempty"`
+	Include               []string         `json:"include"`
+	LineDelimiter         string           `json:"line_delimiter,omitempty"`
+	MaxLineBytes          int              `json:"max_line_bytes,omitempty"`
+	MaxReadBytes          int              `json:"max_read_bytes,omitempty"`
+	Multiline             *Multiline       `json:"multiline,omitempty"`
+	OldestFirst           *bool            `json:"oldest_first,omitempty"`
+	ReadFrom              string           `json:"read_from,omitempty"`
+	RemoveAfterSecs       int              `json:"remove_after_secs,omitempty"`
+}
+
+// SourceType implements Source.
+func (*FileSource) SourceType() string { return "file" }
+
+// FileEncoding configures the character set of the files read by the file source.
+type FileEncoding struct {
+	Charset string `json:"charset"`
+}
+
+// FileFingerprint configures how the file source identifies files.
+type FileFingerprint struct {
+	IgnoredHeaderBytes int    `json:"ignored_header_bytes,omitempty"`
+	Lines              int    `json:"lines,omitempty"`
+	Strategy           string `json:"strategy,omitempty"`
+}
+
+// Multiline configures the aggregation of multiline messages.
+type Multiline struct {
+	ConditionPattern string `json:"condition_pattern"`
+	Mode             string `json:"mode"`
+	StartPattern     string `json:"start_pattern"`
+	TimeoutMs        int    `json:"timeout_ms"`
+}
+
+// JournaldSource collects logs from the systemd journal.
+//
+// https://vector.dev/docs/reference/configuration/sources/journald/
+type JournaldSource struct {
+	BatchSize        int                 `json:"batch_size,omitempty"`
+	CurrentBootOnly  *bool               `json:"current_boot_only,omitempty"`
+	DataDir          string              `json:"data_dir,omitempty"`
+	EmitCursor       *bool               `json:"emit_cursor,omitempty"`
+	ExcludeMatches   map[string][]string `json:"exclude_matches,omitempty"`
+	ExcludeUnits     []string            `json:"exclude_units,omitempty"`
+	IncludeMatches   map[string][]string `json:"include_matches,omitempty"`
+	IncludeUnits     []string            `json:"include_units,omitempty"`
+	JournalDirectory string              `json:"journal_directory,omitempty"`
+	JournalNamespace string              `json:"journal_namespace,omitempty"`
+	JournalctlPath   string              `json:"journalctl_path,omitempty"`
+	SinceNow         *bool               `json:"since_now,omitempty"`
+}
+
+// SourceType implements Source.
+func (*JournaldSource) SourceType() string { return "journald" }
+
+// SyslogSource receives syslog messages over TCP, UDP or a unix socket.
+//
+// https://vector.dev/docs/reference/configuration/sources/syslog/
+type SyslogSource struct {
+	Address            string `json:"address,omitempty"`
+	ConnectionLimit    int    `json:"connection_limit,omitempty"`
+	HostKey            string `json:"host_key,omitempty"`
+	MaxLength          int    `json:"max_length,omitempty"`
+	Mode               string `json:"mode"`
+	Path               string `json:"path,omitempty"`
+	ReceiveBufferBytes int    `json:"receive_buffer_bytes,omitempty"`
+	SocketFileMode     int    `json:"socket_file_mode,omitempty"`
+	TLS                *TLS   `json:"tls,omitempty"`
+}
+
+// SourceType implements Source.
+func (*SyslogSource) SourceType() string { return "syslog" }
+
+// HTTPServerSource receives events over HTTP.
+//
+// https://vector.dev/docs/reference/configuration/sources/http_server/
+type HTTPServerSource struct {
+	Address         string    `json:"address"`
+	Auth            *HTTPAuth `json:"auth,omitempty"`
+	Decoding        *Decoding `json:"decoding,omitempty"`
+	Framing         *Framing  `json:"framing,omitempty"`
+	Headers         []string  `json:"headers,omitempty"`
+	HostKey         string    `json:"host_key,omitempty"`
+	Method          string    `json:"method,omitempty"`
+	Path            string    `json:"path,omitempty"`
+	PathKey         string    `json:"path_key,omitempty"`
+	QueryParameters []string  `json:"query_parameters,omitempty"`
+	ResponseCode    int       `json:"response_code,omitempty"`
+	StrictPath      *bool     `json:"strict_path,omitempty"`
+	TLS             *TLS      `json:"tls,omitempty"`
+}
+
+// SourceType implements Source.
+func (*HTTPServerSource) SourceType() string { return "http_server" }
+
+// PrometheusScrapeSource scrapes prometheus endpoints.
+//
+// https://vector.dev/docs/reference/configuration/sources/prometheus_scrape/
+type PrometheusScrapeSource struct {
+	Auth               *HTTPAuth           `json:"auth,omitempty"`
+	EndpointTag        string              `json:"endpoint_tag,omitempty"`
+	Endpoints          []string            `json:"endpoints"`
+	HonorLabels        *bool               `json:"honor_labels,omitempty"`
+	InstanceTag        string              `json:"instance_tag,omitempty"`
+	Query              map[string][]string `json:"query,omitempty"`
+	ScrapeIntervalSecs float64             `json:"scrape_interval_secs,omitempty"`
+	ScrapeTimeoutSecs  float64             `json:"scrape_timeout_secs,omitempty"`
+	TLS                *TLS                `json:"tls,omitempty"`
+}
+
+// SourceType implements Source.
+func (*PrometheusScrapeSource) SourceType() string { return "prometheus_scrape" }
+
+// OTLPSource receives OpenTelemetry data over gRPC and HTTP.
+//
+// https://vector.dev/docs/reference/configuration/sources/opentelemetry/
+type OTLPSource struct {
+	GRPC            OTLPListener `json:"grpc"`
+	HTTP            OTLPListener `json:"http"`
+	UseOTLPDecoding *bool        `json:"use_otlp_decoding,omitempty"`
+}
+
+// SourceType implements Source.
+func (*OTLPSource) SourceType() string { return "opentelemetry" }
+
+// OTLPListener configures one of the listeners of the opentelemetry source.
+type OTLPListener struct {
+	Address string `json:"address"`
+}
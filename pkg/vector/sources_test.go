@@ -0,0 +1,165 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddSource(t *testing.T) {
+	t.Parallel()
+
+	enabled := true
+	tests := []struct {
+		name string
+		src  Source
+		want string
+	}{
+		{
+			name: "kubernetes_logs",
+			src: &KubernetesLogsSource{
+				ExtraLabelSelector:    "app!=vector",
+				GlobMinimumCooldownMs: 500,
+				PodAnnotationFields:   map[string]string{"pod_name": ".k8s.pod"},
+				UseAPIServerCache:     &enabled,
+			},
+			want: `{
+				"type": "kubernetes_logs",
+				"extra_label_selector": "app!=vector",
+				"glob_minimum_cooldown_ms": 500,
+				"pod_annotation_fields": {"pod_name": ".k8s.pod"},
+				"use_apiserver_cache": true
+			}`,
+		},
+		{
+			name: "host_metrics",
+			src: &HostMetricsSource{
+				Collectors:         []string{"cpu", "filesystem"},
+				Filesystem:         &HostMetricsFilesystem{Mountpoints: &IncludeExclude{Exclude: []string{"/proc/*"}}},
+				ScrapeIntervalSecs: 15,
+				Tags:               &HostMetricsCollectTags{HostKey: "node"},
+			},
+			want: `{
+				"type": "host_metrics",
+				"collectors": ["cpu", "filesystem"],
+				"filesystem": {"mountpoints": {"exclude": ["/proc/*"]}},
+				"scrape_interval_secs": 15,
+				"tags": {"host_key": "node"}
+			}`,
+		},
+		{
+			name: "internal_metrics",
+			src: &InternalMetricsSource{
+				Namespace: "vector",
+				Tags:      &InternalMetricsTags{PidKey: "pid"},
+			},
+			want: `{"type": "internal_metrics", "namespace": "vector", "tags": {"pid_key": "pid"}}`,
+		},
+		{
+			name: "file",
+			src: &FileSource{
+				Include:     []string{"/var/log/*.log"},
+				Fingerprint: &FileFingerprint{Strategy: "device_and_inode"},
+				Multiline: &Multiline{
+					ConditionPattern: `^\s`,
+					Mode:             "continue_through",
+					StartPattern:     `^\S`,
+					TimeoutMs:        1000,
+				},
+				ReadFrom: "beginning",
+			},
+			want: `{
+				"type": "file",
+				"include": ["/var/log/*.log"],
+				"fingerprint": {"strategy": "device_and_inode"},
+				"multiline": {
+					"condition_pattern": "^\\s",
+					"mode": "continue_through",
+					"start_pattern": "^\\S",
+					"timeout_ms": 1000
+				},
+				"read_from": "beginning"
+			}`,
+		},
+		{
+			name: "journald",
+			src: &JournaldSource{
+				CurrentBootOnly: &enabled,
+				IncludeUnits:    []string{"kubelet"},
+				ExcludeMatches:  map[string][]string{"_TRANSPORT": {"kernel"}},
+			},
+			want: `{
+				"type": "journald",
+				"current_boot_only": true,
+				"include_units": ["kubelet"],
+				"exclude_matches": {"_TRANSPORT": ["kernel"]}
+			}`,
+		},
+		{
+			name: "syslog",
+			src:  &SyslogSource{Address: "0.0.0.0:514", Mode: "udp"},
+			want: `{"type": "syslog", "address": "0.0.0.0:514", "mode": "udp"}`,
+		},
+		{
+			name: "http_server",
+			src: &HTTPServerSource{
+				Address:  "0.0.0.0:8080",
+				Auth:     &HTTPAuth{Strategy: "basic", Username: "vector", Password: "SECRET[vault.http]"},
+				Decoding: &Decoding{Codec: "json"},
+				Framing:  &Framing{Method: "newline_delimited"},
+				TLS:      &TLS{CRTFile: "/etc/tls/tls.crt", KeyFile: "/etc/tls/tls.key"},
+			},
+			want: `{
+				"type": "http_server",
+				"address": "0.0.0.0:8080",
+				"auth": {"strategy": "basic", "username": "vector", "password": "SECRET[vault.http]"},
+				"decoding": {"codec": "json"},
+				"framing": {"method": "newline_delimited"},
+				"tls": {"crt_file": "/etc/tls/tls.crt", "key_file": "/etc/tls/tls.key"}
+			}`,
+		},
+		{
+			name: "prometheus_scrape",
+			src: &PrometheusScrapeSource{
+				Endpoints:          []string{"http://localhost:9100/metrics"},
+				HonorLabels:        &enabled,
+				Query:              map[string][]string{"match[]": {`{job="node"}`}},
+				ScrapeIntervalSecs: 30,
+			},
+			want: `{
+				"type": "prometheus_scrape",
+				"endpoints": ["http://localhost:9100/metrics"],
+				"honor_labels": true,
+				"query": {"match[]": ["{job=\"node\"}"]},
+				"scrape_interval_secs": 30
+			}`,
+		},
+		{
+			name: "opentelemetry",
+			src: &OTLPSource{
+				GRPC: OTLPListener{Address: "0.0.0.0:4317"},
+				HTTP: OTLPListener{Address: "0.0.0.0:4318"},
+			},
+			want: `{
+				"type": "opentelemetry",
+				"grpc": {"address": "0.0.0.0:4317"},
+				"http": {"address": "0.0.0.0:4318"}
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.name, tt.src.SourceType())
+
+			vCfg := NewConfig()
+			vCfg.AddSource("src", tt.src)
+
+			got, err := vCfg.JSON()
+			require.NoError(t, err)
+			require.JSONEq(t, `{"sources": {"src": `+tt.want+`}, "sinks": {}}`, got)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package vector
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// testsKey is the top-level key of the unit tests section.
+const testsKey = "tests"
+
+// Types of unit test input.
+const (
+	TestInputLog    = "log"
+	TestInputMetric = "metric"
+	TestInputRaw    = "raw"
+	TestInputVRL    = "vrl"
+)
+
+// Test is a unit test of the configuration's transforms, run by "vector test".
+//
+// https://vector.dev/docs/reference/configuration/unit-tests/
+type Test struct {
+	Inputs        []TestInput  `json:"inputs,omitempty"`
+	Name          string       `json:"name"`
+	NoOutputsFrom []string     `json:"no_outputs_from,omitempty"`
+	Outputs       []TestOutput `json:"outputs,omitempty"`
+}
+
+// TestInput is an event inserted into a transform by a unit test. Type defaults to "raw".
+type TestInput struct {
+	InsertAt  string         `json:"insert_at"`
+	LogFields map[string]any `json:"log_fields,omitempty"`
+	Metric    map[string]any `json:"metric,omitempty"`
+	Source    string         `json:"source,omitempty"`
+	Type      string         `json:"type,omitempty"`
+	Value     string         `json:"value,omitempty"`
+}
+
+// TestOutput holds the conditions the events output by transforms must meet.
+type TestOutput struct {
+	Conditions  []Condition `json:"conditions,omitempty"`
+	ExtractFrom []string    `json:"extract_from"`
+}
+
+// AddTest adds a unit test to the configuration.
+func (c *Config) AddTest(test Test) {
+	must(c.TryAddTest(test))
+}
+
+// TryAddTest is AddTest, returning ErrDuplicateKey instead of panicking if a test of the same name exists.
+func (c *Config) TryAddTest(test Test) error {
+	copied, err := copyTest(test)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.testIndex(test.Name) >= 0 {
+		return fmt.Errorf("%w: %s '%s' already added to configuration", ErrDuplicateKey, KindTest, test.Name)
+	}
+
+	c.internal.Tests = append(c.internal.Tests, copied)
+	return nil
+}
+
+// RemoveTest removes the unit test called name, returning ErrNotFound if there is none.
+func (c *Config) RemoveTest(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.testIndex(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %s '%s'", ErrNotFound, KindTest, name)
+	}
+
+	c.internal.Tests = slices.Delete(c.internal.Tests, i, i+1)
+	return nil
+}
+
+// Tests returns a copy of the unit tests of the configuration, in the order they were added.
+func (c *Config) Tests() []Test {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return copyTests(c.internal.Tests)
+}
+
+// WithoutTests returns a copy of the configuration without its unit tests, to render them into a separate file
+// with RenderTests.
+func (c *Config) WithoutTests() *Config {
+	result := c.Clone()
+	result.internal.Tests = make([]Test, 0)
+	return result
+}
+
+// RenderTests renders only the unit tests of the configuration, as a file to pass to "vector test" alongside the
+// configuration rendered by WithoutTests.
+func (c *Config) RenderTests(format Format) (string, error) {
+	raw, err := json.Marshal(map[string]any{testsKey: c.Tests()})
+	if err != nil {
+		return "", fmt.Errorf("error encoding tests: %w", err)
+	}
+	return renderDocument(format, raw)
+}
+
+// testIndex returns the index of the test called name, or -1. The caller must hold c.mu.
+func (c *Config) testIndex(name string) int {
+	return slices.IndexFunc(c.internal.Tests, func(t Test) bool {
+		return t.Name == name
+	})
+}
+
+// validateTests checks that unit tests insert into and extract from transforms of the configuration, or their named
+// outputs ("route_name.branch"). Every unknown target is reported once per test. The caller must hold c.mu.
+func (c *Config) validateTests(g *graph) []error {
+	errs := make([]error, 0)
+	for _, test := range c.internal.Tests {
+		targets := make([]string, 0)
+		for _, input := range test.Inputs {
+			targets = append(targets, input.InsertAt)
+		}
+		for _, output := range test.Outputs {
+			targets = append(targets, output.ExtractFrom...)
+		}
+		targets = append(targets, test.NoOutputsFrom...)
+
+		reported := make(map[string]bool)
+		for _, target := range targets {
+			if g.isTransformOutput(target) || reported[target] {
+				continue
+			}
+			reported[target] = true
+			errs = append(errs, &ValidationError{
+				Kind:   KindTest,
+				Key:    test.Name,
+				Err:    ErrUnknownTestTarget,
+				Detail: fmt.Sprintf("'%s'", target),
+			})
+		}
+	}
+	return errs
+}
+
+// parseTests decodes the unit tests section of a configuration document.
+func parseTests(value any) ([]Test, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding tests: %w", err)
+	}
+
+	result := make([]Test, 0)
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("'%s' must be a list of tests: %w", testsKey, err)
+	}
+	return result, nil
+}
+
+// copyTest returns a deep copy of a unit test.
+func copyTest(test Test) (Test, error) {
+	raw, err := json.Marshal(test)
+	if err != nil {
+		return Test{}, fmt.Errorf("error encoding test '%s': %w", test.Name, err)
+	}
+
+	var result Test
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return Test{}, fmt.Errorf("error decoding test '%s': %w", test.Name, err)
+	}
+	return result, nil
+}
+
+// copyTests returns a deep copy of unit tests. Tests are only stored once they have been copied successfully, so
+// copying them again cannot fail.
+func copyTests(tests []Test) []Test {
+	result := make([]Test, 0, len(tests))
+	for _, test := range tests {
+		copied, _ := copyTest(test)
+		result = append(result, copied)
+	}
+	return result
+}
@@ -0,0 +1,133 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testedConfig returns a configuration with a remap transform and a unit test of it.
+func testedConfig(t *testing.T) *Config {
+	t.Helper()
+
+	c := NewConfig()
+	c.AddSource("in", new(KubernetesLogsSource))
+	c.AddTransform("parse", &RemapTransform{Inputs: []string{"in"}, Source: ". = parse_json!(.message)"})
+	c.AddSink("out", &BlackholeSink{Inputs: []string{"parse"}})
+	c.AddTest(Test{
+		Name: "parses json",
+		Inputs: []TestInput{{
+			InsertAt: "parse",
+			Type:     TestInputRaw,
+			Value:    `{"level": "info"}`,
+		}},
+		Outputs: []TestOutput{{
+			ExtractFrom: []string{"parse"},
+			Conditions:  []Condition{VRLCondition(`.level == "info"`)},
+		}},
+	})
+	return c
+}
+
+func TestAddTest(t *testing.T) {
+	t.Parallel()
+
+	c := testedConfig(t)
+
+	got, err := c.JSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"sources": {"in": {"type": "kubernetes_logs"}},
+		"transforms": {"parse": {"type": "remap", "inputs": ["in"], "source": ". = parse_json!(.message)"}},
+		"sinks": {"out": {"type": "blackhole", "inputs": ["parse"]}},
+		"tests": [{
+			"name": "parses json",
+			"inputs": [{"insert_at": "parse", "type": "raw", "value": "{\"level\": \"info\"}"}],
+			"outputs": [{"extract_from": ["parse"], "conditions": [".level == \"info\""]}]
+		}]
+	}`, got)
+	require.NoError(t, c.Validate())
+
+	require.ErrorIs(t, c.TryAddTest(Test{Name: "parses json"}), ErrDuplicateKey)
+	require.ErrorIs(t, c.RemoveTest("missing"), ErrNotFound)
+	require.NoError(t, c.RemoveTest("parses json"))
+	require.Empty(t, c.Tests())
+}
+
+func TestParseTests(t *testing.T) {
+	t.Parallel()
+
+	c := testedConfig(t)
+	rendered, err := c.YAML()
+	require.NoError(t, err)
+
+	parsed, err := Parse(FormatYAML, []byte(rendered))
+	require.NoError(t, err)
+	require.Equal(t, c.Tests(), parsed.Tests())
+}
+
+func TestRenderTestsSeparately(t *testing.T) {
+	t.Parallel()
+
+	c := testedConfig(t)
+
+	withoutTests, err := c.WithoutTests().JSON()
+	require.NoError(t, err)
+	require.NotContains(t, withoutTests, `"tests"`)
+	require.Len(t, c.Tests(), 1)
+
+	got, err := c.RenderTests(FormatYAML)
+	require.NoError(t, err)
+	require.Equal(t, `tests:
+- inputs:
+  - insert_at: parse
+    type: raw
+    value: '{"level": "info"}'
+  name: parses json
+  outputs:
+  - conditions:
+    - .level == "info"
+    extract_from:
+    - parse
+`, got)
+}
+
+func TestValidateTests(t *testing.T) {
+	t.Parallel()
+
+	c := testedConfig(t)
+	c.AddTest(Test{
+		Name:          "drops debug",
+		Inputs:        []TestInput{{InsertAt: "filter", Type: TestInputLog, LogFields: map[string]any{"level": "debug"}}},
+		NoOutputsFrom: []string{"filter"},
+	})
+
+	err := c.Validate()
+	require.ErrorIs(t, err, ErrUnknownTestTarget)
+	require.EqualError(t, err, "test \"drops debug\": test refers to a transform that is not defined: 'filter'")
+}
+
+func TestValidateTestsNamedOutput(t *testing.T) {
+	t.Parallel()
+
+	c := testedConfig(t)
+	c.AddTransform("route", &RouteTransform{
+		Inputs: []string{"parse"},
+		Route:  map[string]Condition{"errors": VRLCondition(`.level == "error"`)},
+	})
+	c.AddSink("errors", &BlackholeSink{Inputs: []string{"route.errors", "route._unmatched"}})
+	c.AddTest(Test{
+		Name:    "routes errors",
+		Inputs:  []TestInput{{InsertAt: "route", Type: TestInputLog, LogFields: map[string]any{"level": "error"}}},
+		Outputs: []TestOutput{{ExtractFrom: []string{"route.errors"}}},
+	})
+	c.AddTest(Test{
+		Name:          "drops warnings",
+		Inputs:        []TestInput{{InsertAt: "route", Type: TestInputLog, LogFields: map[string]any{"level": "warn"}}},
+		NoOutputsFrom: []string{"route.warnings"},
+	})
+
+	err := c.Validate()
+	require.EqualError(t, err,
+		"test \"drops warnings\": test refers to a transform that is not defined: 'route.warnings'")
+}
@@ -0,0 +1,125 @@
+package vector
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// topologyColours are the fill colours of the nodes of each kind in rendered topologies.
+var topologyColours = map[ComponentKind]string{
+	KindSource:    "#b7e1cd",
+	KindTransform: "#c9daf8",
+	KindSink:      "#fce5cd",
+}
+
+// topologyEdge is an edge of a rendered topology, from the component producing events to the one consuming them.
+type topologyEdge struct {
+	from string
+	to   string
+
+	// output is the named output the events are read from, empty for the default output.
+	output string
+}
+
+// DOT draws the topology of the configuration as a Graphviz digraph. Nodes are coloured by kind, edges from a named
+// output of a transform are labelled with it and wildcard inputs are expanded. Inputs that do not match any component
+// are left out.
+func (c *Config) DOT() string {
+	g, edges := c.topology()
+
+	var b strings.Builder
+	b.WriteString("digraph vector {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\"];\n")
+	for _, key := range topologyNodes(g) {
+		fmt.Fprintf(&b, "  %q [label=%q, fillcolor=%q];\n", key, key+"\n"+typeOf(g.configs[key]),
+			topologyColours[g.kinds[key]])
+	}
+	for _, edge := range edges {
+		if edge.output == "" {
+			fmt.Fprintf(&b, "  %q -> %q;\n", edge.from, edge.to)
+			continue
+		}
+		fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", edge.from, edge.to, edge.output)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid draws the topology of the configuration as a Mermaid flowchart, following the same rules as DOT.
+func (c *Config) Mermaid() string {
+	g, edges := c.topology()
+
+	nodes := topologyNodes(g)
+	ids := make(map[string]string, len(nodes))
+	for i, key := range nodes {
+		ids[key] = fmt.Sprintf("n%d", i)
+	}
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, kind := range []ComponentKind{KindSource, KindTransform, KindSink} {
+		fmt.Fprintf(&b, "  classDef %s fill:%s\n", kind, topologyColours[kind])
+	}
+	for _, key := range nodes {
+		fmt.Fprintf(&b, "  %s[\"%s<br/>%s\"]:::%s\n", ids[key], mermaidText(key), mermaidText(typeOf(g.configs[key])),
+			g.kinds[key])
+	}
+	for _, edge := range edges {
+		if edge.output == "" {
+			fmt.Fprintf(&b, "  %s --> %s\n", ids[edge.from], ids[edge.to])
+			continue
+		}
+		fmt.Fprintf(&b, "  %s -->|%s| %s\n", ids[edge.from], mermaidText(edge.output), ids[edge.to])
+	}
+	return b.String()
+}
+
+// topology returns the component graph of the configuration and its edges, sorted by consumer, then producer.
+func (c *Config) topology() (*graph, []topologyEdge) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	g := newGraph(c.internal.Sources, c.internal.Transforms, c.internal.Sinks)
+	edges := make([]topologyEdge, 0)
+	for _, key := range sortedKeys(g.configs) {
+		if g.kinds[key] == KindSource {
+			continue
+		}
+
+		for _, input := range componentInputs(g.configs[key]) {
+			upstream, err := g.resolve(key, input)
+			if err != nil {
+				continue
+			}
+
+			var output string
+			if !strings.Contains(input, "*") && g.kinds[input] == "" {
+				_, output, _ = strings.Cut(input, ".")
+			}
+			for _, u := range upstream {
+				edge := topologyEdge{from: u, to: key, output: output}
+				if !slices.Contains(edges, edge) {
+					edges = append(edges, edge)
+				}
+			}
+		}
+	}
+	return g, edges
+}
+
+// topologyNodes returns the keys of the components of the graph, sources first, then transforms, then sinks.
+func topologyNodes(g *graph) []string {
+	order := map[ComponentKind]int{KindSource: 0, KindTransform: 1, KindSink: 2}
+	nodes := sortedKeys(g.configs)
+	slices.SortStableFunc(nodes, func(a, b string) int {
+		return order[g.kinds[a]] - order[g.kinds[b]]
+	})
+	return nodes
+}
+
+// mermaidText escapes text for a Mermaid label.
+func mermaidText(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "|", "#124;").Replace(s)
+}
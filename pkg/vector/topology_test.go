@@ -0,0 +1,62 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// topologyConfig returns a configuration with a route, a wildcard input and an input that does not resolve.
+func topologyConfig(t *testing.T) *Config {
+	t.Helper()
+
+	c := NewConfig()
+	c.AddSource("app_logs", new(KubernetesLogsSource))
+	c.AddSource("app_journal", new(JournaldSource))
+	require.NoError(t, Pipeline("logs").
+		FromExisting("app_*").
+		Through(Step("route", &RouteTransform{Route: map[string]Condition{"errors": VRLCondition(`.level == "error"`)}})).
+		Branch("errors").
+		To("alerts", &HTTPSink{URI: "http://alertmanager", Encoding: Encoding{Codec: "json"}}).
+		Build(c))
+	c.AddSink("archive", &BlackholeSink{Inputs: []string{"route._unmatched", "missing"}})
+	return c
+}
+
+func TestDOT(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, `digraph vector {
+  rankdir=LR;
+  node [shape=box, style="rounded,filled"];
+  "app_journal" [label="app_journal\njournald", fillcolor="#b7e1cd"];
+  "app_logs" [label="app_logs\nkubernetes_logs", fillcolor="#b7e1cd"];
+  "route" [label="route\nroute", fillcolor="#c9daf8"];
+  "alerts" [label="alerts\nhttp", fillcolor="#fce5cd"];
+  "archive" [label="archive\nblackhole", fillcolor="#fce5cd"];
+  "route" -> "alerts" [label="errors"];
+  "route" -> "archive" [label="_unmatched"];
+  "app_journal" -> "route";
+  "app_logs" -> "route";
+}
+`, topologyConfig(t).DOT())
+}
+
+func TestMermaid(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, `flowchart LR
+  classDef source fill:#b7e1cd
+  classDef transform fill:#c9daf8
+  classDef sink fill:#fce5cd
+  n0["app_journal<br/>journald"]:::source
+  n1["app_logs<br/>kubernetes_logs"]:::source
+  n2["route<br/>route"]:::transform
+  n3["alerts<br/>http"]:::sink
+  n4["archive<br/>blackhole"]:::sink
+  n2 -->|errors| n3
+  n2 -->|_unmatched| n4
+  n0 --> n2
+  n1 --> n2
+`, topologyConfig(t).Mermaid())
+}
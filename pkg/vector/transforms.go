@@ -0,0 +1,219 @@
+package vector
+
+import "encoding/json"
+
+// Transform is a typed vector transform configuration.
+type Transform interface {
+	// TransformType returns the vector type of the transform.
+	TransformType() string
+}
+
+// AddTransform adds the specified typed transform under key.
+func (c *Config) AddTransform(key string, t Transform) {
+	must(c.TryAddTransform(key, t))
+}
+
+// TryAddTransform is AddTransform, returning an error instead of panicking.
+func (c *Config) TryAddTransform(key string, t Transform) error {
+	cfg, err := componentMap(t.TransformType(), t)
+	if err != nil {
+		return err
+	}
+	return c.TryAddTransformUntyped(key, cfg)
+}
+
+// ReplaceTransform replaces the transform under key with a typed transform, returning ErrNotFound if there is none.
+func (c *Config) ReplaceTransform(key string, t Transform) error {
+	cfg, err := componentMap(t.TransformType(), t)
+	if err != nil {
+		return err
+	}
+	return c.ReplaceTransformUntyped(key, cfg)
+}
+
+// Condition is a condition evaluated against events by transforms such as filter and route.
+//
+// A condition with no type is rendered in vector's shorthand form, which is a bare VRL expression.
+type Condition struct {
+	// Type is the condition type, e.g. "vrl", "datadog_search" or "is_log".
+	Type string `json:"type,omitempty"`
+
+	// Source is the condition expression.
+	Source string `json:"source,omitempty"`
+}
+
+// VRLCondition returns a condition using the shorthand VRL form.
+func VRLCondition(source string) Condition {
+	return Condition{
+		Source: source,
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c Condition) MarshalJSON() ([]byte, error) {
+	if c.Type == "" {
+		return json.Marshal(c.Source)
+	}
+
+	type condition Condition
+	return json.Marshal(condition(c))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting both the shorthand and the typed form.
+func (c *Condition) UnmarshalJSON(data []byte) error {
+	var source string
+	if err := json.Unmarshal(data, &source); err == nil {
+		*c = VRLCondition(source)
+		return nil
+	}
+
+	type condition Condition
+	return json.Unmarshal(data, (*condition)(c))
+}
+
+// RemapTransform modifies events using the vector remap language.
+//
+// https://vector.dev/docs/reference/configuration/transforms/remap/
+type RemapTransform struct {
+	DropOnAbort     *bool    `json:"drop_on_abort,omitempty"`
+	DropOnError     *bool    `json:"drop_on_error,omitempty"`
+	File            string   `json:"file,omitempty"`
+	Inputs          []string `json:"inputs"`
+	MetricTagValues string   `json:"metric_tag_values,omitempty"`
+	RerouteDropped  *bool    `json:"reroute_dropped,omitempty"`
+	Source          string   `json:"source,omitempty"`
+	Timezone        string   `json:"timezone,omitempty"`
+}
+
+// TransformType implements Transform.
+func (*RemapTransform) TransformType() string { return "remap" }
+
+// FilterTransform drops events that do not match the condition.
+//
+// https://vector.dev/docs/reference/configuration/transforms/filter/
+type FilterTransform struct {
+	Condition Condition `json:"condition"`
+	Inputs    []string  `json:"inputs"`
+}
+
+// TransformType implements Transform.
+func (*FilterTransform) TransformType() string { return "filter" }
+
+// RouteTransform splits events into named outputs, which are referenced as "<key>.<route>" by downstream
+// components.
+//
+// https://vector.dev/docs/reference/configuration/transforms/route/
+type RouteTransform struct {
+	Inputs           []string             `json:"inputs"`
+	RerouteUnmatched *bool                `json:"reroute_unmatched,omitempty"`
+	Route            map[string]Condition `json:"route"`
+}
+
+// TransformType implements Transform.
+func (*RouteTransform) TransformType() string { return "route" }
+
+// ReduceTransform collapses multiple events into a single event.
+//
+// https://vector.dev/docs/reference/configuration/transforms/reduce/
+type ReduceTransform struct {
+	EndEveryPeriodMs int               `json:"end_every_period_ms,omitempty"`
+	EndsWhen         *Condition        `json:"ends_when,omitempty"`
+	ExpireAfterMs    int               `json:"expire_after_ms,omitempty"`
+	FlushPeriodMs    int               `json:"flush_period_ms,omitempty"`
+	GroupBy          []string          `json:"group_by,omitempty"`
+	Inputs           []string          `json:"inputs"`
+	MaxEvents        int               `json:"max_events,omitempty"`
+	MergeStrategies  map[string]string `json:"merge_strategies,omitempty"`
+	StartsWhen       *Condition        `json:"starts_when,omitempty"`
+}
+
+// TransformType implements Transform.
+func (*ReduceTransform) TransformType() string { return "reduce" }
+
+// DedupeTransform drops duplicate events.
+//
+// https://vector.dev/docs/reference/configuration/transforms/dedupe/
+type DedupeTransform struct {
+	Cache  *DedupeCache  `json:"cache,omitempty"`
+	Fields *DedupeFields `json:"fields,omitempty"`
+	Inputs []string      `json:"inputs"`
+}
+
+// TransformType implements Transform.
+func (*DedupeTransform) TransformType() string { return "dedupe" }
+
+// DedupeCache configures the cache of the dedupe transform.
+type DedupeCache struct {
+	NumEvents int `json:"num_events"`
+}
+
+// DedupeFields configures which fields the dedupe transform compares. Only one of Match and Ignore may be set.
+type DedupeFields struct {
+	Ignore []string `json:"ignore,omitempty"`
+	Match  []string `json:"match,omitempty"`
+}
+
+// ThrottleTransform rate limits events.
+//
+// https://vector.dev/docs/reference/configuration/transforms/throttle/
+type ThrottleTransform struct {
+	Exclude    *Condition `json:"exclude,omitempty"`
+	Inputs     []string   `json:"inputs"`
+	KeyField   string     `json:"key_field,omitempty"`
+	Threshold  int        `json:"threshold"`
+	WindowSecs float64    `json:"window_secs"`
+}
+
+// TransformType implements Transform.
+func (*ThrottleTransform) TransformType() string { return "throttle" }
+
+// SampleTransform samples events at a configurable rate.
+//
+// https://vector.dev/docs/reference/configuration/transforms/sample/
+type SampleTransform struct {
+	Exclude       *Condition `json:"exclude,omitempty"`
+	GroupBy       string     `json:"group_by,omitempty"`
+	Inputs        []string   `json:"inputs"`
+	KeyField      string     `json:"key_field,omitempty"`
+	Rate          int        `json:"rate,omitempty"`
+	Ratio         float64    `json:"ratio,omitempty"`
+	SampleRateKey string     `json:"sample_rate_key,omitempty"`
+}
+
+// TransformType implements Transform.
+func (*SampleTransform) TransformType() string { return "sample" }
+
+// LogToMetricTransform derives metrics from log events.
+//
+// https://vector.dev/docs/reference/configuration/transforms/log_to_metric/
+type LogToMetricTransform struct {
+	AllMetrics *bool               `json:"all_metrics,omitempty"`
+	Inputs     []string            `json:"inputs"`
+	Metrics    []LogToMetricMetric `json:"metrics"`
+}
+
+// TransformType implements Transform.
+func (*LogToMetricTransform) TransformType() string { return "log_to_metric" }
+
+// LogToMetricMetric describes a single metric produced by the log_to_metric transform.
+type LogToMetricMetric struct {
+	Field            string            `json:"field"`
+	IncrementByValue *bool             `json:"increment_by_value,omitempty"`
+	Kind             string            `json:"kind,omitempty"`
+	Name             string            `json:"name,omitempty"`
+	Namespace        string            `json:"namespace,omitempty"`
+	Tags             map[string]string `json:"tags,omitempty"`
+	Type             string            `json:"type"`
+}
+
+// AggregateTransform aggregates metrics over an interval.
+//
+// https://vector.dev/docs/reference/configuration/transforms/aggregate/
+type AggregateTransform struct {
+	Inputs     []string `json:"inputs"`
+	IntervalMs int      `json:"interval_ms,omitempty"`
+	Mode       string   `json:"mode,omitempty"`
+}
+
+// TransformType implements Transform.
+func (*AggregateTransform) TransformType() string { return "aggregate" }
@@ -0,0 +1,47 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddTransform(t *testing.T) {
+	t.Parallel()
+
+	vCfg := NewConfig()
+	vCfg.AddSource("kubernetes_logs", new(KubernetesLogsSource))
+	vCfg.AddTransform("only_errors", &FilterTransform{
+		Inputs:    []string{"kubernetes_logs"},
+		Condition: VRLCondition(`.level == "error"`),
+	})
+	vCfg.AddTransform("by_namespace", &RouteTransform{
+		Inputs: []string{"only_errors"},
+		Route: map[string]Condition{
+			"system": {Type: "vrl", Source: `.kubernetes.pod_namespace == "kube-system"`},
+		},
+	})
+
+	got, err := vCfg.JSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"sources": {
+			"kubernetes_logs": {"type": "kubernetes_logs"}
+		},
+		"transforms": {
+			"only_errors": {
+				"type": "filter",
+				"inputs": ["kubernetes_logs"],
+				"condition": ".level == \"error\""
+			},
+			"by_namespace": {
+				"type": "route",
+				"inputs": ["only_errors"],
+				"route": {
+					"system": {"type": "vrl", "source": ".kubernetes.pod_namespace == \"kube-system\""}
+				}
+			}
+		},
+		"sinks": {}
+	}`, got)
+}
@@ -0,0 +1,340 @@
+package vector
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrDuplicateKey is returned when a key is used by more than one component.
+	ErrDuplicateKey = errors.New("duplicate component key")
+
+	// ErrMissingInput is returned when an input does not refer to a known component or output.
+	ErrMissingInput = errors.New("input does not match any component")
+
+	// ErrNoInputs is returned when a transform or sink has no inputs.
+	ErrNoInputs = errors.New("component has no inputs")
+
+	// ErrCycle is returned when transforms form a cycle.
+	ErrCycle = errors.New("transforms form a cycle")
+
+	// ErrUnusedComponent is returned when the output of a source or transform is not consumed.
+	ErrUnusedComponent = errors.New("component output is not consumed")
+
+	// ErrUnknownEnrichmentTable is returned when a remap transform reads an enrichment table that is not defined.
+	ErrUnknownEnrichmentTable = errors.New("enrichment table is not defined")
+
+	// ErrUndeclaredSecretBackend is returned when a secret reference names a secret backend that is not declared.
+	ErrUndeclaredSecretBackend = errors.New("secret backend is not declared")
+
+	// ErrUnknownTestTarget is returned when a unit test inserts into or extracts from a transform that is not defined.
+	ErrUnknownTestTarget = errors.New("test refers to a transform that is not defined")
+)
+
+// ValidationError is a single problem found while validating a configuration.
+type ValidationError struct {
+	// Kind is the kind of the component the problem was found on.
+	Kind ComponentKind
+
+	// Key is the key of the component the problem was found on.
+	Key string
+
+	// Err is the sentinel describing the problem.
+	Err error
+
+	// Detail adds context to Err, e.g. the name of the missing input.
+	Detail string
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("%s %q: %s", e.Kind, e.Key, e.Err)
+	}
+	return fmt.Sprintf("%s %q: %s: %s", e.Kind, e.Key, e.Err, e.Detail)
+}
+
+// Unwrap returns the sentinel error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks the component keys and topology of the configuration, its references to enrichment tables and
+// secret backends and the transforms its unit tests refer to, returning every problem found joined into a single
+// error.
+//
+// Inputs may refer to sources and transforms directly, to named outputs of a transform ("route_name.branch") or
+// use wildcards ("app_*").
+func (c *Config) Validate() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	g := newGraph(c.internal.Sources, c.internal.Transforms, c.internal.Sinks)
+	errs := c.validateKeys()
+	errs = append(errs, g.validate()...)
+	errs = append(errs, c.validateEnrichmentTables()...)
+	errs = append(errs, c.validateSecretReferences()...)
+	errs = append(errs, c.validateTests(g)...)
+	return errors.Join(errs...)
+}
+
+// validateEnrichmentTables checks that the enrichment tables read by remap transforms are defined. The caller must
+// hold c.mu.
+func (c *Config) validateEnrichmentTables() []error {
+	errs := make([]error, 0)
+	for _, key := range sortedKeys(c.internal.Transforms) {
+		cfg := c.internal.Transforms[key]
+		source, _ := cfg["source"].(string)
+		if typeOf(cfg) != "remap" || source == "" {
+			continue
+		}
+
+		reported := make(map[string]bool)
+		for _, table := range enrichmentTableReferences(source) {
+			if _, ok := c.internal.EnrichmentTables[table]; ok || reported[table] {
+				continue
+			}
+			reported[table] = true
+			errs = append(errs, &ValidationError{
+				Kind:   KindTransform,
+				Key:    key,
+				Err:    ErrUnknownEnrichmentTable,
+				Detail: fmt.Sprintf("'%s'", table),
+			})
+		}
+	}
+	return errs
+}
+
+// graph is the component graph of a configuration.
+type graph struct {
+	kinds      map[string]ComponentKind
+	configs    map[string]map[string]any
+	duplicates []*ValidationError
+
+	// edges maps a component key to the keys of the components it consumes from.
+	edges map[string][]string
+}
+
+// newGraph builds the component graph of the given components.
+func newGraph(sources, transforms, sinks map[string]map[string]any) *graph {
+	g := &graph{
+		kinds:   make(map[string]ComponentKind),
+		configs: make(map[string]map[string]any),
+		edges:   make(map[string][]string),
+	}
+
+	for _, set := range []struct {
+		kind       ComponentKind
+		components map[string]map[string]any
+	}{
+		{KindSource, sources},
+		{KindTransform, transforms},
+		{KindSink, sinks},
+	} {
+		for _, key := range sortedKeys(set.components) {
+			if existing, ok := g.kinds[key]; ok {
+				g.duplicates = append(g.duplicates, &ValidationError{
+					Kind:   set.kind,
+					Key:    key,
+					Err:    ErrDuplicateKey,
+					Detail: fmt.Sprintf("also used by a %s", existing),
+				})
+				continue
+			}
+			g.kinds[key] = set.kind
+			g.configs[key] = set.components[key]
+		}
+	}
+
+	return g
+}
+
+// validate returns every problem found in the graph.
+func (g *graph) validate() []error {
+	errs := make([]error, 0, len(g.duplicates))
+	for _, dup := range g.duplicates {
+		errs = append(errs, dup)
+	}
+
+	consumed := make(map[string]bool)
+	for _, key := range sortedKeys(g.configs) {
+		kind := g.kinds[key]
+		if kind == KindSource {
+			continue
+		}
+
+		inputs := componentInputs(g.configs[key])
+		if len(inputs) == 0 {
+			errs = append(errs, &ValidationError{Kind: kind, Key: key, Err: ErrNoInputs})
+			continue
+		}
+
+		for _, input := range inputs {
+			upstream, err := g.resolve(key, input)
+			if err != nil {
+				errs = append(errs, &ValidationError{Kind: kind, Key: key, Err: ErrMissingInput, Detail: err.Error()})
+				continue
+			}
+			for _, u := range upstream {
+				consumed[u] = true
+				if !slices.Contains(g.edges[key], u) {
+					g.edges[key] = append(g.edges[key], u)
+				}
+			}
+		}
+	}
+
+	for _, key := range sortedKeys(g.configs) {
+		kind := g.kinds[key]
+		if kind != KindSink && !consumed[key] {
+			errs = append(errs, &ValidationError{Kind: kind, Key: key, Err: ErrUnusedComponent})
+		}
+	}
+
+	for _, cycle := range g.cycles() {
+		errs = append(errs, &ValidationError{
+			Kind:   KindTransform,
+			Key:    cycle[0],
+			Err:    ErrCycle,
+			Detail: strings.Join(cycle, " -> "),
+		})
+	}
+
+	return errs
+}
+
+// resolve returns the keys of the components the input of consumer refers to. Wildcards never match the consumer
+// itself, and wildcards on a named output ("route.*") match the transforms with a matching output.
+func (g *graph) resolve(consumer, input string) ([]string, error) {
+	if strings.Contains(input, "*") {
+		keyPattern, outputPattern, named := strings.Cut(input, ".")
+		matches := make([]string, 0)
+		for _, key := range sortedKeys(g.configs) {
+			if key == consumer || g.kinds[key] == KindSink || (named && g.kinds[key] != KindTransform) {
+				continue
+			}
+			if ok, _ := path.Match(keyPattern, key); !ok {
+				continue
+			}
+			if named && !slices.ContainsFunc(transformOutputs(g.configs[key]), func(output string) bool {
+				ok, _ := path.Match(outputPattern, output)
+				return ok
+			}) {
+				continue
+			}
+			matches = append(matches, key)
+		}
+		if len(matches) == 0 {
+			if named {
+				return nil, fmt.Errorf("wildcard %q matches no transform outputs", input)
+			}
+			return nil, fmt.Errorf("wildcard %q matches no sources or transforms", input)
+		}
+		return matches, nil
+	}
+
+	if kind, ok := g.kinds[input]; ok {
+		if kind == KindSink {
+			return nil, fmt.Errorf("%q is a sink", input)
+		}
+		return []string{input}, nil
+	}
+
+	key, output, ok := strings.Cut(input, ".")
+	if !ok {
+		return nil, fmt.Errorf("%q is not defined", input)
+	}
+	if g.kinds[key] != KindTransform {
+		return nil, fmt.Errorf("%q is not defined", input)
+	}
+	if !slices.Contains(transformOutputs(g.configs[key]), output) {
+		return nil, fmt.Errorf("transform %q has no output %q", key, output)
+	}
+	return []string{key}, nil
+}
+
+// isTransformOutput reports whether target is a transform or one of its named outputs.
+func (g *graph) isTransformOutput(target string) bool {
+	if g.kinds[target] == KindTransform {
+		return true
+	}
+	key, output, ok := strings.Cut(target, ".")
+	return ok && g.kinds[key] == KindTransform && slices.Contains(transformOutputs(g.configs[key]), output)
+}
+
+// cycles returns the cycles between transforms, each starting and ending with the same key.
+func (g *graph) cycles() [][]string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int)
+	stack := make([]string, 0)
+	result := make([][]string, 0)
+
+	var visit func(key string)
+	visit = func(key string) {
+		state[key] = visiting
+		stack = append(stack, key)
+
+		for _, upstream := range g.edges[key] {
+			if g.kinds[upstream] != KindTransform {
+				continue
+			}
+			switch state[upstream] {
+			case unvisited:
+				visit(upstream)
+			case visiting:
+				start := slices.Index(stack, upstream)
+				cycle := slices.Clone(stack[start:])
+				slices.Reverse(cycle)
+				result = append(result, append([]string{upstream}, cycle...))
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[key] = visited
+	}
+
+	for _, key := range sortedKeys(g.configs) {
+		if g.kinds[key] == KindTransform && state[key] == unvisited {
+			visit(key)
+		}
+	}
+
+	return result
+}
+
+// transformOutputs returns the named outputs of a transform.
+func transformOutputs(cfg map[string]any) []string {
+	outputs := make([]string, 0)
+	switch typeOf(cfg) {
+	case "route":
+		switch routes := cfg["route"].(type) {
+		case map[string]any:
+			outputs = append(outputs, sortedKeys(routes)...)
+		case map[string]string:
+			outputs = append(outputs, sortedKeys(routes)...)
+		}
+		if reroute, ok := cfg["reroute_unmatched"].(bool); !ok || reroute {
+			outputs = append(outputs, "_unmatched")
+		}
+	case "remap":
+		if reroute, ok := cfg["reroute_dropped"].(bool); ok && reroute {
+			outputs = append(outputs, "dropped")
+		}
+	}
+	return outputs
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
@@ -0,0 +1,159 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		build   func(c *Config)
+		wantErr []error
+	}{
+		{
+			name: "valid",
+			build: func(c *Config) {
+				c.AddSource("app_a", new(KubernetesLogsSource))
+				c.AddSource("app_b", new(KubernetesLogsSource))
+				c.AddTransform("split", &RouteTransform{
+					Inputs: []string{"app_*"},
+					Route: map[string]Condition{
+						"errors": VRLCondition(`.level == "error"`),
+					},
+				})
+				c.AddSink("errors", &ConsoleSink{
+					Inputs:   []string{"split.errors"},
+					Encoding: Encoding{Codec: "json"},
+				})
+				c.AddSink("rest", &BlackholeSink{
+					Inputs: []string{"split._unmatched"},
+				})
+			},
+		},
+		{
+			name: "wildcard on named outputs",
+			build: func(c *Config) {
+				c.AddSource("logs", new(KubernetesLogsSource))
+				c.AddTransform("split", &RouteTransform{
+					Inputs: []string{"logs"},
+					Route:  map[string]Condition{"errors": VRLCondition(`.level == "error"`)},
+				})
+				c.AddSink("out", &BlackholeSink{Inputs: []string{"split.*"}})
+			},
+		},
+		{
+			name: "wildcard excludes its consumer",
+			build: func(c *Config) {
+				c.AddSource("app_logs", new(KubernetesLogsSource))
+				c.AddTransform("app_parse", &RemapTransform{Inputs: []string{"app_*"}, Source: "."})
+				c.AddSink("out", &BlackholeSink{Inputs: []string{"app_parse"}})
+			},
+		},
+		{
+			name: "wildcard on missing named outputs",
+			build: func(c *Config) {
+				c.AddSource("logs", new(KubernetesLogsSource))
+				c.AddTransform("parse", &RemapTransform{Inputs: []string{"logs"}, Source: "."})
+				c.AddSink("out", &BlackholeSink{Inputs: []string{"parse", "parse.*"}})
+			},
+			wantErr: []error{ErrMissingInput},
+		},
+		{
+			name: "missing input",
+			build: func(c *Config) {
+				c.AddSource("logs", new(KubernetesLogsSource))
+				c.AddSink("out", &BlackholeSink{Inputs: []string{"logs", "nope"}})
+			},
+			wantErr: []error{ErrMissingInput},
+		},
+		{
+			name: "unknown route output",
+			build: func(c *Config) {
+				c.AddSource("logs", new(KubernetesLogsSource))
+				c.AddTransform("split", &RouteTransform{
+					Inputs: []string{"logs"},
+					Route:  map[string]Condition{"a": VRLCondition("true")},
+				})
+				c.AddSink("out", &BlackholeSink{Inputs: []string{"split.a", "split.b"}})
+			},
+			wantErr: []error{ErrMissingInput},
+		},
+		{
+			name: "wildcard matching nothing",
+			build: func(c *Config) {
+				c.AddSource("logs", new(KubernetesLogsSource))
+				c.AddSink("out", &BlackholeSink{Inputs: []string{"logs", "app_*"}})
+			},
+			wantErr: []error{ErrMissingInput},
+		},
+		{
+			name: "sink without inputs",
+			build: func(c *Config) {
+				c.AddSource("logs", new(KubernetesLogsSource))
+				c.AddSink("out", &BlackholeSink{Inputs: []string{"logs"}})
+				c.AddSinkUntyped("empty", map[string]any{"type": "blackhole"})
+			},
+			wantErr: []error{ErrNoInputs},
+		},
+		{
+			name: "unused source and transform",
+			build: func(c *Config) {
+				c.AddSource("logs", new(KubernetesLogsSource))
+				c.AddSource("metrics", new(InternalMetricsSource))
+				c.AddTransform("parse", &RemapTransform{Inputs: []string{"logs"}, Source: ". = parse_json!(.message)"})
+				c.AddSink("out", &BlackholeSink{Inputs: []string{"logs"}})
+			},
+			wantErr: []error{ErrUnusedComponent},
+		},
+		{
+			name: "cycle",
+			build: func(c *Config) {
+				c.AddSource("logs", new(KubernetesLogsSource))
+				c.AddTransform("a", &RemapTransform{Inputs: []string{"logs", "b"}, Source: "."})
+				c.AddTransform("b", &RemapTransform{Inputs: []string{"a"}, Source: "."})
+				c.AddSink("out", &BlackholeSink{Inputs: []string{"b"}})
+			},
+			wantErr: []error{ErrCycle},
+		},
+		{
+			name: "duplicate key across kinds",
+			build: func(c *Config) {
+				c.AddSource("logs", new(KubernetesLogsSource))
+				c.AddSink("logs", &BlackholeSink{Inputs: []string{"logs"}})
+			},
+			wantErr: []error{ErrDuplicateKey},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := NewConfig()
+			tt.build(c)
+
+			err := c.Validate()
+			if len(tt.wantErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, want := range tt.wantErr {
+				require.ErrorIs(t, err, want)
+			}
+		})
+	}
+}
+
+func TestValidateErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	c := NewConfig()
+	c.AddSource("logs", new(KubernetesLogsSource))
+	c.AddSink("out", &BlackholeSink{Inputs: []string{"logs", "nope"}})
+
+	require.EqualError(t, c.Validate(), `sink "out": input does not match any component: "nope" is not defined`)
+}